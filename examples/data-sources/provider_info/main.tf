@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+data "edgio_provider_info" "current" {}
+
+output "provider_info" {
+  value = data.edgio_provider_info.current
+}
@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+variable "environment_id" { type = string }
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+resource "edgio_cdn_configuration" "my_cdn_configuration" {
+  environment_id = var.environment_id
+
+  rules = jsonencode([
+    {
+      "if": [
+        { "==": [{ "request": "path" }, "/api/:path*"] },
+        { "origin": { "set_origin": "api" }, "caching": { "bypass_cache": true } }
+      ]
+    }
+  ])
+
+  # origins and hostnames omitted
+}
+
+data "edgio_rules_simulation" "api_request" {
+  rules = edgio_cdn_configuration.my_cdn_configuration.rules
+
+  request = {
+    method = "GET"
+    host   = "cdn.edgio-terraform-example.com"
+    path   = "/api/users"
+  }
+}
+
+output "api_origin" {
+  value = data.edgio_rules_simulation.api_request.origin
+}
@@ -0,0 +1,75 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+variable "organization_id" { type = string }
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+resource "edgio_property" "my_property" {
+  organization_id = var.organization_id
+  slug = "edgio-config-set-example"
+}
+
+resource "edgio_environment" "staging" {
+  property_id = edgio_property.my_property.id
+  name        = "staging"
+}
+
+resource "edgio_environment" "production" {
+  property_id = edgio_property.my_property.id
+  name        = "production"
+}
+
+resource "edgio_cdn_configuration_set" "my_cdn_configuration_set" {
+  rules = jsonencode([
+    {
+      "if" : [
+        { "==" : [{ "request" : "path" }, "/:path*"] },
+        { "origin" : { "set_origin" : "web" } }
+      ]
+    }
+  ])
+
+  origins = [{
+    name = "web"
+    hosts = [{
+      location = [{ hostname = "staging.origin.example.com", port = 443 }]
+    }]
+  }]
+
+  hostnames = [{
+    hostname            = "staging.example.com"
+    default_origin_name = "web"
+  }]
+
+  environment {
+    environment_id = edgio_environment.staging.id
+  }
+
+  environment {
+    environment_id = edgio_environment.production.id
+
+    origins = [{
+      name = "web"
+      hosts = [{
+        location = [{ hostname = "origin.example.com", port = 443 }]
+      }]
+    }]
+
+    hostnames = [{
+      hostname            = "www.example.com"
+      default_origin_name = "web"
+    }]
+  }
+}
@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+variable "staging_environment_id" { type = string }
+variable "production_environment_id" { type = string }
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+resource "edgio_environment_promotion" "production" {
+  source_environment_id = var.staging_environment_id
+  target_environment_id = var.production_environment_id
+
+  hostname_mapping = {
+    "staging.example.com" = "www.example.com"
+  }
+
+  origin_hostname_mapping = {
+    "staging.origin.example.com" = "origin.example.com"
+  }
+
+  origin_name_mapping = {
+    "staging-origin" = "production-origin"
+  }
+}
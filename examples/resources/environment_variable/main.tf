@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+variable "environment_id" { type = string }
+variable "api_token" {
+  type      = string
+  sensitive = true
+}
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+resource "edgio_environment_variable" "api_url" {
+  environment_id = var.environment_id
+  key            = "API_URL"
+  value          = "https://api.example.com"
+}
+
+# The token is never stored in the state, bump value_wo_version to rotate it.
+resource "edgio_environment_variable" "api_token" {
+  environment_id   = var.environment_id
+  key              = "API_TOKEN"
+  value_wo         = var.api_token
+  value_wo_version = 1
+  secret           = true
+}
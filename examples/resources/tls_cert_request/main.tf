@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    edgio = {
+      source = "Edgio/edgio"
+      version = "0.1.0"
+    }
+  }
+}
+
+variable "client_id" { type = string }
+variable "client_secret" {  type = string }
+variable "environment_id" { type = string }
+
+provider "edgio" {
+  client_id     = var.client_id
+  client_secret = var.client_secret
+}
+
+resource "edgio_tls_cert_request" "my_cert_request" {
+  environment_id = var.environment_id
+  key_algorithm  = "ECDSA"
+  ecdsa_curve    = "P256"
+  common_name    = "cdn.edgio-terraform-example.com"
+  dns_names      = ["cdn.edgio-terraform-example.com", "www.edgio-terraform-example.com"]
+
+  # Leave signed_cert unset for the first apply, hand cert_request_pem to your CA
+  # and set signed_cert to the issued certificate afterwards.
+  # signed_cert       = file("signed.pem")
+  # intermediate_cert = file("intermediate.pem")
+}
+
+output "cert_request_pem" {
+  value = edgio_tls_cert_request.my_cert_request.cert_request_pem
+}
@@ -0,0 +1,256 @@
+package edgio_api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// jwtBearerGrantType is the OAuth 2.0 JWT bearer grant (RFC 7523) used to
+// exchange an OIDC token for access tokens.
+const jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+// Credentials select how the client authenticates. Exactly one of
+// ClientSecret, AccessToken, AccessTokenFile, OIDCToken and OIDCTokenFile
+// must be set, ClientID is required with ClientSecret and the OIDC tokens.
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+
+	// AccessToken is a pre-issued access token used for all scopes. Its
+	// scopes are only known from the scope claim if it is a JWT.
+	AccessToken string
+
+	// AccessTokenFile contains an access token that is read again once it
+	// expires, so an external process can rotate it.
+	AccessTokenFile string
+
+	// OIDCToken is a JWT of an OIDC identity provider, e.g. a CI workload
+	// identity, exchanged for access tokens with the JWT bearer grant.
+	OIDCToken string
+
+	// OIDCTokenFile contains the OIDC JWT, it is read for every exchange.
+	OIDCTokenFile string
+}
+
+// tokenExpiryMargin is subtracted from the expiry of cached tokens, so that a
+// token is not sent right before it expires or while clocks are off.
+const tokenExpiryMargin = time.Minute
+
+// Scopes are the scopes the client requests tokens for.
+var Scopes = []string{"app.accounts", "app.config"}
+
+// AuthenticatedScopes returns the scopes the client obtains tokens for. It
+// only fails if no token can be obtained at all. Pre-issued access tokens are
+// used for every scope, so their scopes are taken from the scope claim of the
+// JWT, without verifying it. Tokens that are no JWTs or have no scope claim
+// return no scopes, as they cannot be checked without calling the API.
+func (c *EdgioClient) AuthenticatedScopes() ([]string, error) {
+	if c.credentials.AccessToken != "" || c.credentials.AccessTokenFile != "" {
+		token, err := c.getToken(Scopes[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+
+		claimed, _ := jwtScopes(token)
+		var scopes []string
+		for _, scope := range Scopes {
+			if slices.Contains(claimed, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+		return scopes, nil
+	}
+
+	var scopes []string
+	var lastErr error
+	for _, scope := range Scopes {
+		if _, err := c.getToken(scope); err != nil {
+			lastErr = err
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("failed to get a token for any of the scopes %v: %w", Scopes, lastErr)
+	}
+
+	return scopes, nil
+}
+
+func (c *EdgioClient) getToken(scope string) (string, error) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	if cachedToken, exists := c.tokenCache[scope]; exists && time.Now().Before(cachedToken.Expiry) {
+		return cachedToken.AccessToken, nil
+	}
+
+	switch {
+	case c.credentials.AccessToken != "":
+		return c.credentials.AccessToken, nil
+	case c.credentials.AccessTokenFile != "":
+		return c.readTokenFile(scope)
+	case c.credentials.OIDCToken != "" || c.credentials.OIDCTokenFile != "":
+		assertion, err := c.oidcToken()
+		if err != nil {
+			return "", err
+		}
+
+		return c.requestToken(scope, map[string]string{
+			"client_id":  c.credentials.ClientID,
+			"grant_type": jwtBearerGrantType,
+			"assertion":  assertion,
+			"scope":      scope,
+		})
+	default:
+		return c.requestToken(scope, map[string]string{
+			"client_id":     c.credentials.ClientID,
+			"client_secret": c.credentials.ClientSecret,
+			"grant_type":    "client_credentials",
+			"scope":         scope,
+		})
+	}
+}
+
+// requestToken requests an access token from the token endpoint and caches it
+// until tokenExpiryMargin before it expires.
+func (c *EdgioClient) requestToken(scope string, form map[string]string) (string, error) {
+	var tokenResp AccessTokenResponse
+	resp, err := c.client.R().
+		SetFormData(form).
+		SetResult(&tokenResp).
+		Post(c.tokenURL)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to request token: %w", err)
+	}
+
+	if resp.IsError() {
+		return "", fmt.Errorf("unexpected status code for getToken: %d (request ID %s)", resp.StatusCode(), requestID(resp))
+	}
+
+	c.tokenCache[scope] = TokenCache{
+		AccessToken: tokenResp.AccessToken,
+		Expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - tokenExpiryMargin),
+	}
+
+	return tokenResp.AccessToken, nil
+}
+
+// readTokenFile reads the access token file. Tokens are cached until
+// tokenExpiryMargin before the expiry of their JWT claims, other tokens are
+// read again for every request.
+func (c *EdgioClient) readTokenFile(scope string) (string, error) {
+	token, err := readToken(c.credentials.AccessTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read access token file: %w", err)
+	}
+
+	if expiry, ok := jwtExpiry(token); ok {
+		c.tokenCache[scope] = TokenCache{
+			AccessToken: token,
+			Expiry:      expiry.Add(-tokenExpiryMargin),
+		}
+	}
+
+	return token, nil
+}
+
+func (c *EdgioClient) oidcToken() (string, error) {
+	if c.credentials.OIDCToken != "" {
+		return c.credentials.OIDCToken, nil
+	}
+
+	token, err := readToken(c.credentials.OIDCTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token file: %w", err)
+	}
+
+	return token, nil
+}
+
+func readToken(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+
+	return token, nil
+}
+
+// jwtClaims holds the claims of a JWT the client reads.
+type jwtClaims struct {
+	Exp int64 `json:"exp"`
+
+	// Scope is a space-separated string, or a list with some identity
+	// providers.
+	Scope any `json:"scope"`
+}
+
+// decodeJWTClaims decodes the claims of a JWT, without verifying it. It
+// returns false for tokens that are no JWTs.
+func decodeJWTClaims(token string) (jwtClaims, bool) {
+	var claims jwtClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, false
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+
+	return claims, true
+}
+
+// jwtExpiry returns the expiry of a JWT, without verifying it. It returns
+// false for tokens that are no JWTs or do not expire.
+func jwtExpiry(token string) (time.Time, bool) {
+	claims, ok := decodeJWTClaims(token)
+	if !ok || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
+
+// jwtScopes returns the scope claim of a JWT, without verifying it. It returns
+// false for tokens that are no JWTs or have no scope claim.
+func jwtScopes(token string) ([]string, bool) {
+	claims, ok := decodeJWTClaims(token)
+	if !ok {
+		return nil, false
+	}
+
+	switch scope := claims.Scope.(type) {
+	case string:
+		return strings.Fields(scope), true
+	case []any:
+		var scopes []string
+		for _, s := range scope {
+			if s, ok := s.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes, true
+	default:
+		return nil, false
+	}
+}
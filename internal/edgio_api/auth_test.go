@@ -0,0 +1,186 @@
+package edgio_api_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"terraform-provider-edgio/internal/edgio_api"
+)
+
+// newAPIServer records the bearer token of every request to the API.
+func newAPIServer(t *testing.T, tokens *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*tokens = append(*tokens, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"env-123"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func jwt(exp time.Time) string {
+	return jwtWithClaims(fmt.Sprintf(`{"exp":%d}`, exp.Unix()))
+}
+
+func jwtWithClaims(claims string) string {
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestEdgioClient_AccessTokenFile(t *testing.T) {
+	var tokens []string
+	api := newAPIServer(t, &tokens)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	client, err := edgio_api.NewEdgioClient(edgio_api.Credentials{AccessTokenFile: tokenFile}, edgio_api.TransportOptions{}, "", api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getWithToken := func(token string) {
+		if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetEnvironment("env-123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Tokens that are no JWTs are read for every request.
+	getWithToken("opaque-1")
+	getWithToken("opaque-2")
+
+	// JWTs are cached until shortly before they expire.
+	expiringJWT := jwt(time.Now().Add(30 * time.Second))
+	getWithToken(expiringJWT)
+	validJWT := jwt(time.Now().Add(time.Hour))
+	getWithToken(validJWT)
+	getWithToken("opaque-3")
+
+	expected := []string{"Bearer opaque-1", "Bearer opaque-2", "Bearer " + expiringJWT, "Bearer " + validJWT, "Bearer " + validJWT}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("expected tokens %v, got %v", expected, tokens)
+	}
+}
+
+func TestEdgioClient_OIDCTokenFile(t *testing.T) {
+	var tokens []string
+	api := newAPIServer(t, &tokens)
+
+	exchanges := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" ||
+			r.Form.Get("assertion") != "oidc-jwt" || r.Form.Get("client_id") != "client-123" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access-%s","expires_in":300}`, r.Form.Get("scope"))
+	}))
+	defer tokenServer.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "oidc")
+	if err := os.WriteFile(tokenFile, []byte("oidc-jwt"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := edgio_api.NewEdgioClient(
+		edgio_api.Credentials{ClientID: "client-123", OIDCTokenFile: tokenFile},
+		edgio_api.TransportOptions{},
+		tokenServer.URL,
+		api.URL,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetEnvironment("env-123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if exchanges != 1 {
+		t.Errorf("expected the access token to be cached, got %d exchanges", exchanges)
+	}
+	if tokens[0] != "Bearer access-app.accounts" {
+		t.Errorf("unexpected token %s", tokens[0])
+	}
+}
+
+func TestEdgioClient_AuthenticatedScopesOfAccessToken(t *testing.T) {
+	tests := map[string]struct {
+		token  string
+		scopes []string
+	}{
+		"string claim": {
+			token:  jwtWithClaims(`{"scope":"app.config openid"}`),
+			scopes: []string{"app.config"},
+		},
+		"list claim": {
+			token:  jwtWithClaims(`{"scope":["app.accounts","app.config"]}`),
+			scopes: []string{"app.accounts", "app.config"},
+		},
+		"no claim": {
+			token: jwtWithClaims(`{"sub":"ci"}`),
+		},
+		"opaque token": {
+			token: "opaque-token",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := edgio_api.NewEdgioClient(edgio_api.Credentials{AccessToken: test.token}, edgio_api.TransportOptions{}, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scopes, err := client.AuthenticatedScopes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(scopes) != fmt.Sprint(test.scopes) {
+				t.Errorf("expected scopes %v, got %v", test.scopes, scopes)
+			}
+		})
+	}
+}
+
+func TestEdgioClient_ShortLivedTokensAreNotCached(t *testing.T) {
+	var tokens []string
+	api := newAPIServer(t, &tokens)
+
+	requests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"short-lived","expires_in":30}`)
+	}))
+	defer tokenServer.Close()
+
+	client, err := edgio_api.NewEdgioClient(
+		edgio_api.Credentials{ClientID: "client-123", ClientSecret: "secret"},
+		edgio_api.TransportOptions{},
+		tokenServer.URL,
+		api.URL,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetEnvironment("env-123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("expected a token expiring within the margin to be requested again, got %d requests", requests)
+	}
+}
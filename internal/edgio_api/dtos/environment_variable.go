@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+// EnvironmentVariable is a variable of an environment. The API does not return
+// the value of secret variables.
+type EnvironmentVariable struct {
+	IdLink        string    `json:"@id"`
+	Id            string    `json:"id"`
+	EnvironmentID string    `json:"environment_id"`
+	Key           string    `json:"key"`
+	Value         string    `json:"value"`
+	Secret        bool      `json:"secret"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -2,14 +2,20 @@ package edgio_api
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"terraform-provider-edgio/internal/edgio_api/dtos"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
+// ErrNotFound is wrapped by the errors of requests the API answers with 404 Not
+// Found, e.g. because the object was deleted outside of Terraform.
+var ErrNotFound = errors.New("not found")
+
 // AccessTokenResponse represents the response from the token endpoint.
 type AccessTokenResponse struct {
 	AccessToken string `json:"access_token"`
@@ -28,61 +34,31 @@ type TokenCache struct {
 }
 
 type EdgioClient struct {
-	client       *resty.Client
-	clientID     string
-	clientSecret string
-	tokenURL     string
-	apiURL       string
-	tokenCache   map[string]TokenCache
+	client      *resty.Client
+	credentials Credentials
+	tokenURL    string
+	apiURL      string
+	tokenCache  map[string]TokenCache
+	tokenMutex  sync.Mutex
 }
 
-func NewEdgioClient(clientID, clientSecret, tokenURL, apiURL string) *EdgioClient {
+func NewEdgioClient(credentials Credentials, transport TransportOptions, tokenURL, apiURL string) (*EdgioClient, error) {
 	client := resty.New().
-		SetTimeout(30 * time.Second).
 		SetRetryCount(3).
 		SetRetryWaitTime(5 * time.Second).
 		SetRetryMaxWaitTime(20 * time.Second)
 
-	return &EdgioClient{
-		client:       client,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		tokenURL:     tokenURL,
-		apiURL:       apiURL,
-		tokenCache:   make(map[string]TokenCache),
-	}
-}
-
-func (c *EdgioClient) getToken(scope string) (string, error) {
-	if cachedToken, exists := c.tokenCache[scope]; exists && time.Now().Before(cachedToken.Expiry) {
-		return cachedToken.AccessToken, nil
-	}
-
-	var tokenResp AccessTokenResponse
-	resp, err := c.client.R().
-		SetFormData(map[string]string{
-			"client_id":     c.clientID,
-			"client_secret": c.clientSecret,
-			"grant_type":    "client_credentials",
-			"scope":         scope,
-		}).
-		SetResult(&tokenResp).
-		Post(c.tokenURL)
-
-	if err != nil {
-		return "", fmt.Errorf("failed to request token: %w", err)
-	}
-
-	if resp.IsError() {
-		return "", fmt.Errorf("unexpected status code for getToken: %d", resp.StatusCode())
-	}
-
-	c.tokenCache[scope] = TokenCache{
-		AccessToken: tokenResp.AccessToken,
-		Expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	if err := configureTransport(client, transport); err != nil {
+		return nil, err
 	}
 
-	return tokenResp.AccessToken, nil
+	return &EdgioClient{
+		client:      client,
+		credentials: credentials,
+		tokenURL:    tokenURL,
+		apiURL:      apiURL,
+		tokenCache:  make(map[string]TokenCache),
+	}, nil
 }
 
 func (c *EdgioClient) GetProperty(ctx context.Context, propertyID string) (*dtos.Property, error) {
@@ -105,7 +81,7 @@ func (c *EdgioClient) GetProperty(ctx context.Context, propertyID string) (*dtos
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for getSpecificProperty: %d, %s", resp.StatusCode(), resp.Request.URL)
+		return nil, fmt.Errorf("unexpected status code for getSpecificProperty: %d, %s (request ID %s)", resp.StatusCode(), resp.Request.URL, requestID(resp))
 	}
 
 	return &property, nil
@@ -135,7 +111,7 @@ func (c *EdgioClient) GetProperties(page int, pageSize int, organizationID strin
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for getProperties: %d, %s", resp.StatusCode(), resp.Body())
+		return nil, fmt.Errorf("unexpected status code for getProperties: %d, %s (request ID %s)", resp.StatusCode(), resp.Body(), requestID(resp))
 	}
 
 	return &propertiesResp, nil
@@ -166,7 +142,7 @@ func (c *EdgioClient) CreateProperty(ctx context.Context, organizationID, slug s
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for createProperty: %d, response: %s", resp.StatusCode(), resp.String())
+		return nil, fmt.Errorf("unexpected status code for createProperty: %d, response: %s (request ID %s)", resp.StatusCode(), resp.String(), requestID(resp))
 	}
 
 	return &createdProperty, nil
@@ -189,7 +165,7 @@ func (c *EdgioClient) DeleteProperty(propertyID string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("error deleting property: status code %d", resp.StatusCode())
+		return fmt.Errorf("error deleting property: status code %d (request ID %s)", resp.StatusCode(), requestID(resp))
 	}
 
 	return nil
@@ -220,7 +196,7 @@ func (c *EdgioClient) UpdateProperty(ctx context.Context, propertyID string, slu
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for updateProperty: %d", resp.StatusCode())
+		return nil, fmt.Errorf("unexpected status code for updateProperty: %d (request ID %s)", resp.StatusCode(), requestID(resp))
 	}
 
 	return &updatedProperty, nil
@@ -249,7 +225,7 @@ func (c *EdgioClient) GetEnvironments(page, pageSize int, propertyID string) (*d
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("error response: %s", resp.String())
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return resp.Result().(*dtos.EnvironmentsResponse), nil
@@ -276,7 +252,7 @@ func (c *EdgioClient) GetEnvironment(environmentID string) (*dtos.Environment, e
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("error response: %s", resp.String())
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return resp.Result().(*dtos.Environment), nil
@@ -308,7 +284,7 @@ func (c *EdgioClient) CreateEnvironment(propertyID, name string, onlyMaintainers
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("error response: %s", resp.String())
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return resp.Result().(*dtos.Environment), nil
@@ -345,7 +321,7 @@ func (c *EdgioClient) UpdateEnvironment(environmentID, name string, onlyMaintain
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("error response: %s", resp.String())
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return resp.Result().(*dtos.Environment), nil
@@ -372,7 +348,121 @@ func (c *EdgioClient) DeleteEnvironment(environmentID string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("error response: %s", resp.String())
+		return fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
+	}
+
+	return nil
+}
+
+func (c *EdgioClient) GetEnvironmentVariable(variableID string) (*dtos.EnvironmentVariable, error) {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/environment-variables/%s", c.apiURL, variableID)
+
+	resp, err := c.client.R().
+		SetAuthToken(token).
+		SetResult(&dtos.EnvironmentVariable{}).
+		Get(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("environment variable %s: %w (request ID %s)", variableID, ErrNotFound, requestID(resp))
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
+	}
+
+	return resp.Result().(*dtos.EnvironmentVariable), nil
+}
+
+func (c *EdgioClient) CreateEnvironmentVariable(environmentID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/environment-variables", c.apiURL)
+
+	body := map[string]interface{}{
+		"environment_id": environmentID,
+		"key":            key,
+		"value":          value,
+		"secret":         secret,
+	}
+
+	resp, err := c.client.R().
+		SetBody(body).
+		SetAuthToken(token).
+		SetResult(&dtos.EnvironmentVariable{}).
+		Post(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
+	}
+
+	return resp.Result().(*dtos.EnvironmentVariable), nil
+}
+
+func (c *EdgioClient) UpdateEnvironmentVariable(variableID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/environment-variables/%s", c.apiURL, variableID)
+
+	body := map[string]interface{}{
+		"key":    key,
+		"value":  value,
+		"secret": secret,
+	}
+
+	resp, err := c.client.R().
+		SetBody(body).
+		SetAuthToken(token).
+		SetResult(&dtos.EnvironmentVariable{}).
+		Patch(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
+	}
+
+	return resp.Result().(*dtos.EnvironmentVariable), nil
+}
+
+func (c *EdgioClient) DeleteEnvironmentVariable(variableID string) error {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/environment-variables/%s", c.apiURL, variableID)
+
+	resp, err := c.client.R().
+		SetAuthToken(token).
+		Delete(url)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return nil
@@ -396,8 +486,12 @@ func (c *EdgioClient) GetTlsCert(tlsCertId string) (*dtos.TLSCertResponse, error
 		return nil, fmt.Errorf("error response: %s", err)
 	}
 
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("TLS certificate %s: %w (request ID %s)", tlsCertId, ErrNotFound, requestID(resp))
+	}
+
 	if resp.IsError() {
-		return nil, fmt.Errorf("error response: %s", resp.String())
+		return nil, fmt.Errorf("error response: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return &tlsCertResponse, nil
@@ -424,7 +518,7 @@ func (c *EdgioClient) UploadTlsCert(req dtos.UploadTlsCertRequest) (*dtos.TLSCer
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API responded with error: %s", resp.String())
+		return nil, fmt.Errorf("API responded with error: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return response, nil
@@ -454,7 +548,7 @@ func (c *EdgioClient) GenerateTlsCert(environmentId string) (*dtos.TLSCertRespon
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API responded with error: %s", resp.String())
+		return nil, fmt.Errorf("API responded with error: %s (request ID %s)", resp.String(), requestID(resp))
 	}
 
 	return response, nil
@@ -484,16 +578,36 @@ func (c *EdgioClient) GetTlsCerts(page int, pageSize int, environmentID string)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for getTlsCerts: %d", resp.StatusCode())
+		return nil, fmt.Errorf("unexpected status code for getTlsCerts: %d (request ID %s)", resp.StatusCode(), requestID(resp))
 	}
 
 	return &tlsCertsResponse, nil
 }
 
-func (c *EdgioClient) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dtos.CDNConfiguration, error) {
+func (c *EdgioClient) DeleteTlsCert(tlsCertID string) error {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/tls-certs/%s", c.apiURL, tlsCertID)
+
+	resp, err := c.client.R().
+		SetAuthToken(token).
+		Delete(url)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete TLS certificate: %w", err)
+	}
 
-	fmt.Println("------------------------------------------------------------------------- uploading")
+	if resp.IsError() {
+		return fmt.Errorf("API responded with error: %s (request ID %s)", resp.String(), requestID(resp))
+	}
 
+	return nil
+}
+
+func (c *EdgioClient) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dtos.CDNConfiguration, error) {
 	token, err := c.getToken("app.config")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token: %w", err)
@@ -502,13 +616,6 @@ func (c *EdgioClient) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dt
 	url := fmt.Sprintf("%s/config/v0.1/configs", c.apiURL)
 	var response dtos.CDNConfiguration
 
-	// Convert config to json
-	jsonBody, _ := json.MarshalIndent(config, "", "    ")
-	jsonString := string(jsonBody)
-	fmt.Println("------------------------- config report code: ", config.Hostnames[0].ReportCode == nil)
-	fmt.Println("------------------------- config report code value: ", config.Hostnames[0].ReportCode)
-	fmt.Println("----------------------------------- jsonBody: ", jsonString)
-
 	resp, err := c.client.R().
 		SetAuthToken(token).
 		SetHeader("Content-Type", "application/json").
@@ -521,21 +628,19 @@ func (c *EdgioClient) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dt
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for uploadCdnConfiguration: %d, %s", resp.StatusCode(), resp.Body())
+		return nil, fmt.Errorf("unexpected status code for uploadCdnConfiguration: %d, %s (request ID %s)", resp.StatusCode(), resp.Body(), requestID(resp))
 	}
 
 	return &response, nil
 }
 
 func (c *EdgioClient) GetCDNConfiguration(configID string) (*dtos.CDNConfiguration, error) {
-	fmt.Println("------------------------------------------------------------------------- reading config")
-
 	token, err := c.getToken("app.config")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
 
-	url := fmt.Sprintf("https://edgioapis.com/config/v0.1/configs/%s", configID)
+	url := fmt.Sprintf("%s/config/v0.1/configs/%s", c.apiURL, configID)
 	var response dtos.CDNConfiguration
 
 	resp, err := c.client.R().
@@ -548,7 +653,40 @@ func (c *EdgioClient) GetCDNConfiguration(configID string) (*dtos.CDNConfigurati
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("unexpected status code for GetCDNConfiguration: %d", resp.StatusCode())
+		return nil, fmt.Errorf("unexpected status code for GetCDNConfiguration: %d (request ID %s)", resp.StatusCode(), requestID(resp))
+	}
+
+	return &response, nil
+}
+
+// GetEnvironmentCDNConfiguration returns the configuration active on the
+// environment, which is the configuration uploaded to it last. It wraps
+// ErrNotFound if no configuration was uploaded to the environment yet.
+func (c *EdgioClient) GetEnvironmentCDNConfiguration(environmentID string) (*dtos.CDNConfiguration, error) {
+	token, err := c.getToken("app.config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/config/v0.1/configs", c.apiURL)
+	var response dtos.CDNConfiguration
+
+	resp, err := c.client.R().
+		SetAuthToken(token).
+		SetQueryParam("environment_id", environmentID).
+		SetResult(&response).
+		Get(url)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CDN configuration of environment %s: %w", environmentID, err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("CDN configuration of environment %s: %w (request ID %s)", environmentID, ErrNotFound, requestID(resp))
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("unexpected status code for GetEnvironmentCDNConfiguration: %d (request ID %s)", resp.StatusCode(), requestID(resp))
 	}
 
 	return &response, nil
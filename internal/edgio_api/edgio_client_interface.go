@@ -6,6 +6,7 @@ import (
 )
 
 type EdgioClientInterface interface {
+	AuthenticatedScopes() ([]string, error)
 	GetProperty(ctx context.Context, propertyID string) (*dtos.Property, error)
 	GetProperties(page int, pageSize int, organizationID string) (*dtos.Properties, error)
 	CreateProperty(ctx context.Context, organizationID, slug string) (*dtos.Property, error)
@@ -16,10 +17,16 @@ type EdgioClientInterface interface {
 	CreateEnvironment(propertyID, name string, onlyMaintainersCanDeploy, httpRequestLogging bool) (*dtos.Environment, error)
 	UpdateEnvironment(environmentID, name string, onlyMaintainersCanDeploy, httpRequestLogging, preserveCache bool) (*dtos.Environment, error)
 	DeleteEnvironment(environmentID string) error
+	GetEnvironmentVariable(variableID string) (*dtos.EnvironmentVariable, error)
+	CreateEnvironmentVariable(environmentID, key, value string, secret bool) (*dtos.EnvironmentVariable, error)
+	UpdateEnvironmentVariable(variableID, key, value string, secret bool) (*dtos.EnvironmentVariable, error)
+	DeleteEnvironmentVariable(variableID string) error
 	GetTlsCert(tlsCertId string) (*dtos.TLSCertResponse, error)
 	UploadTlsCert(req dtos.UploadTlsCertRequest) (*dtos.TLSCertResponse, error)
 	GenerateTlsCert(environmentId string) (*dtos.TLSCertResponse, error)
 	GetTlsCerts(page int, pageSize int, environmentID string) (*dtos.TLSCertSResponse, error)
+	DeleteTlsCert(tlsCertID string) error
 	UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dtos.CDNConfiguration, error)
 	GetCDNConfiguration(configID string) (*dtos.CDNConfiguration, error)
+	GetEnvironmentCDNConfiguration(environmentID string) (*dtos.CDNConfiguration, error)
 }
@@ -11,6 +11,14 @@ type MockEdgioClient struct {
 	mock.Mock
 }
 
+func (m *MockEdgioClient) AuthenticatedScopes() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockEdgioClient) GetProperty(ctx context.Context, propertyID string) (*dtos.Property, error) {
 	args := m.Called(ctx, propertyID)
 	return args.Get(0).(*dtos.Property), args.Error(1)
@@ -71,6 +79,26 @@ func (m *MockEdgioClient) GetPurgeStatus(requestId string) (*dtos.PurgeResponse,
 	return args.Get(0).(*dtos.PurgeResponse), args.Error(1)
 }
 
+func (m *MockEdgioClient) GetEnvironmentVariable(variableID string) (*dtos.EnvironmentVariable, error) {
+	args := m.Called(variableID)
+	return args.Get(0).(*dtos.EnvironmentVariable), args.Error(1)
+}
+
+func (m *MockEdgioClient) CreateEnvironmentVariable(environmentID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	args := m.Called(environmentID, key, value, secret)
+	return args.Get(0).(*dtos.EnvironmentVariable), args.Error(1)
+}
+
+func (m *MockEdgioClient) UpdateEnvironmentVariable(variableID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	args := m.Called(variableID, key, value, secret)
+	return args.Get(0).(*dtos.EnvironmentVariable), args.Error(1)
+}
+
+func (m *MockEdgioClient) DeleteEnvironmentVariable(variableID string) error {
+	args := m.Called(variableID)
+	return args.Error(0)
+}
+
 func (m *MockEdgioClient) GetTlsCert(tlsCertId string) (*dtos.TLSCertResponse, error) {
 	args := m.Called(tlsCertId)
 	return args.Get(0).(*dtos.TLSCertResponse), args.Error(1)
@@ -91,6 +119,11 @@ func (m *MockEdgioClient) GetTlsCerts(page int, pageSize int, environmentID stri
 	return args.Get(0).(*dtos.TLSCertSResponse), args.Error(1)
 }
 
+func (m *MockEdgioClient) DeleteTlsCert(tlsCertID string) error {
+	args := m.Called(tlsCertID)
+	return args.Error(0)
+}
+
 func (m *MockEdgioClient) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dtos.CDNConfiguration, error) {
 	args := m.Called(config)
 	return args.Get(0).(*dtos.CDNConfiguration), args.Error(1)
@@ -101,5 +134,10 @@ func (m *MockEdgioClient) GetCDNConfiguration(configID string) (*dtos.CDNConfigu
 	return args.Get(0).(*dtos.CDNConfiguration), args.Error(1)
 }
 
+func (m *MockEdgioClient) GetEnvironmentCDNConfiguration(environmentID string) (*dtos.CDNConfiguration, error) {
+	args := m.Called(environmentID)
+	return args.Get(0).(*dtos.CDNConfiguration), args.Error(1)
+}
+
 // Ensure MockEdgioClient implements EdgioClientInterface.
 var _ EdgioClientInterface = (*MockEdgioClient)(nil)
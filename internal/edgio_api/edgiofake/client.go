@@ -0,0 +1,537 @@
+// Package edgiofake provides a stateful in-memory Edgio API for tests: a fake
+// client implementing edgio_api.EdgioClientInterface and an HTTP server
+// serving it to the real client. It is only imported by tests, so the
+// provider binary does not contain it.
+package edgiofake
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"time"
+)
+
+var (
+	errNotFound   = edgio_api.ErrNotFound
+	errBadRequest = errors.New("bad request")
+)
+
+// Client is a stateful in-memory implementation of
+// edgio_api.EdgioClientInterface for tests. Unlike edgio_api.MockEdgioClient it needs no
+// expectations: created objects get IDs, can be read, listed page by page,
+// updated and deleted, and unknown IDs return not found errors.
+//
+// Deleting a property that still has environments fails, deleting an
+// environment deletes its variables, TLS certificates and configurations.
+type Client struct {
+	mutex  sync.Mutex
+	nextID int
+
+	properties   map[string]*dtos.Property
+	environments map[string]*dtos.Environment
+	variables    map[string]*dtos.EnvironmentVariable
+	tlsCerts     map[string]*dtos.TLSCertResponse
+	configs      map[string]*dtos.CDNConfiguration
+
+	// activeConfigs holds the ID of the configuration uploaded last to each
+	// environment.
+	activeConfigs map[string]string
+}
+
+var _ edgio_api.EdgioClientInterface = &Client{}
+
+func NewClient() *Client {
+	return &Client{
+		properties:   make(map[string]*dtos.Property),
+		environments: make(map[string]*dtos.Environment),
+		variables:    make(map[string]*dtos.EnvironmentVariable),
+		tlsCerts:     make(map[string]*dtos.TLSCertResponse),
+		configs:      make(map[string]*dtos.CDNConfiguration),
+
+		activeConfigs: make(map[string]string),
+	}
+}
+
+// newID returns IDs that sort in creation order.
+func (f *Client) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%06d", prefix, f.nextID)
+}
+
+func (f *Client) AuthenticatedScopes() ([]string, error) {
+	return append([]string(nil), edgio_api.Scopes...), nil
+}
+
+func (f *Client) GetProperty(_ context.Context, propertyID string) (*dtos.Property, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	property, ok := f.properties[propertyID]
+	if !ok {
+		return nil, fmt.Errorf("property %s: %w", propertyID, errNotFound)
+	}
+
+	return clone(property), nil
+}
+
+func (f *Client) GetProperties(page int, pageSize int, organizationID string) (*dtos.Properties, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var items []dtos.Property
+	for _, property := range sortedValues(f.properties) {
+		if property.OrganizationID == organizationID {
+			items = append(items, *clone(property))
+		}
+	}
+
+	return &dtos.Properties{
+		TotalItems: len(items),
+		Items:      paginate(items, page, pageSize),
+	}, nil
+}
+
+func (f *Client) CreateProperty(_ context.Context, organizationID, slug string) (*dtos.Property, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if organizationID == "" || slug == "" {
+		return nil, fmt.Errorf("organization_id and slug are required: %w", errBadRequest)
+	}
+	for _, property := range f.properties {
+		if property.OrganizationID == organizationID && property.Slug == slug {
+			return nil, fmt.Errorf("slug %s is already used by property %s: %w", slug, property.Id, errBadRequest)
+		}
+	}
+
+	now := fakeNow()
+	property := &dtos.Property{
+		Id:             f.newID("property"),
+		OrganizationID: organizationID,
+		Slug:           slug,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	property.IdLink = "/accounts/v0.1/properties/" + property.Id
+	f.properties[property.Id] = property
+
+	return clone(property), nil
+}
+
+func (f *Client) DeleteProperty(propertyID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.properties[propertyID]; !ok {
+		return fmt.Errorf("property %s: %w", propertyID, errNotFound)
+	}
+	for _, environment := range f.environments {
+		if environment.PropertyID == propertyID {
+			return fmt.Errorf("property %s has environments: %w", propertyID, errBadRequest)
+		}
+	}
+
+	delete(f.properties, propertyID)
+	return nil
+}
+
+func (f *Client) UpdateProperty(_ context.Context, propertyID string, slug string) (*dtos.Property, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	property, ok := f.properties[propertyID]
+	if !ok {
+		return nil, fmt.Errorf("property %s: %w", propertyID, errNotFound)
+	}
+
+	property.Slug = slug
+	property.UpdatedAt = fakeNow()
+	return clone(property), nil
+}
+
+func (f *Client) GetEnvironments(page, pageSize int, propertyID string) (*dtos.EnvironmentsResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var items []dtos.Environment
+	for _, environment := range sortedValues(f.environments) {
+		if environment.PropertyID == propertyID {
+			items = append(items, *clone(environment))
+		}
+	}
+
+	return &dtos.EnvironmentsResponse{
+		TotalItems: len(items),
+		Items:      paginate(items, page, pageSize),
+	}, nil
+}
+
+func (f *Client) GetEnvironment(environmentID string) (*dtos.Environment, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	environment, ok := f.environments[environmentID]
+	if !ok {
+		return nil, fmt.Errorf("environment %s: %w", environmentID, errNotFound)
+	}
+
+	return clone(environment), nil
+}
+
+func (f *Client) CreateEnvironment(propertyID, name string, onlyMaintainersCanDeploy, httpRequestLogging bool) (*dtos.Environment, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.properties[propertyID]; !ok {
+		return nil, fmt.Errorf("property %s: %w", propertyID, errNotFound)
+	}
+	for _, environment := range f.environments {
+		if environment.PropertyID == propertyID && environment.Name == name {
+			return nil, fmt.Errorf("environment %s already exists: %w", name, errBadRequest)
+		}
+	}
+
+	now := fakeNow()
+	environment := &dtos.Environment{
+		Id:                       f.newID("env"),
+		PropertyID:               propertyID,
+		Name:                     name,
+		CanMembersDeploy:         !onlyMaintainersCanDeploy,
+		OnlyMaintainersCanDeploy: onlyMaintainersCanDeploy,
+		HttpRequestLogging:       httpRequestLogging,
+		CreatedAt:                now,
+		UpdatedAt:                now,
+	}
+	environment.IdLink = "/accounts/v0.1/environments/" + environment.Id
+	environment.DefaultDomainName = fmt.Sprintf("%s-%s.edgio.link", f.properties[propertyID].Slug, name)
+	f.environments[environment.Id] = environment
+
+	return clone(environment), nil
+}
+
+func (f *Client) UpdateEnvironment(environmentID, name string, onlyMaintainersCanDeploy, httpRequestLogging, preserveCache bool) (*dtos.Environment, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	environment, ok := f.environments[environmentID]
+	if !ok {
+		return nil, fmt.Errorf("environment %s: %w", environmentID, errNotFound)
+	}
+
+	environment.Name = name
+	environment.CanMembersDeploy = !onlyMaintainersCanDeploy
+	environment.OnlyMaintainersCanDeploy = onlyMaintainersCanDeploy
+	environment.HttpRequestLogging = httpRequestLogging
+	environment.UpdatedAt = fakeNow()
+	return clone(environment), nil
+}
+
+func (f *Client) DeleteEnvironment(environmentID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.environments[environmentID]; !ok {
+		return fmt.Errorf("environment %s: %w", environmentID, errNotFound)
+	}
+
+	delete(f.environments, environmentID)
+	for id, variable := range f.variables {
+		if variable.EnvironmentID == environmentID {
+			delete(f.variables, id)
+		}
+	}
+	for id, cert := range f.tlsCerts {
+		if cert.EnvironmentID == environmentID {
+			delete(f.tlsCerts, id)
+		}
+	}
+	for id, config := range f.configs {
+		if config.EnvironmentID == environmentID {
+			delete(f.configs, id)
+		}
+	}
+	delete(f.activeConfigs, environmentID)
+
+	return nil
+}
+
+func (f *Client) GetEnvironmentVariable(variableID string) (*dtos.EnvironmentVariable, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	variable, ok := f.variables[variableID]
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s: %w", variableID, errNotFound)
+	}
+
+	return hideSecret(variable), nil
+}
+
+func (f *Client) CreateEnvironmentVariable(environmentID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.environments[environmentID]; !ok {
+		return nil, fmt.Errorf("environment %s: %w", environmentID, errNotFound)
+	}
+	for _, variable := range f.variables {
+		if variable.EnvironmentID == environmentID && variable.Key == key {
+			return nil, fmt.Errorf("environment variable %s already exists: %w", key, errBadRequest)
+		}
+	}
+
+	now := fakeNow()
+	variable := &dtos.EnvironmentVariable{
+		Id:            f.newID("var"),
+		EnvironmentID: environmentID,
+		Key:           key,
+		Value:         value,
+		Secret:        secret,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	variable.IdLink = "/config/v0.1/environment-variables/" + variable.Id
+	f.variables[variable.Id] = variable
+
+	return hideSecret(variable), nil
+}
+
+func (f *Client) UpdateEnvironmentVariable(variableID, key, value string, secret bool) (*dtos.EnvironmentVariable, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	variable, ok := f.variables[variableID]
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s: %w", variableID, errNotFound)
+	}
+
+	variable.Key = key
+	variable.Value = value
+	variable.Secret = secret
+	variable.UpdatedAt = fakeNow()
+	return hideSecret(variable), nil
+}
+
+func (f *Client) DeleteEnvironmentVariable(variableID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.variables[variableID]; !ok {
+		return fmt.Errorf("environment variable %s: %w", variableID, errNotFound)
+	}
+
+	delete(f.variables, variableID)
+	return nil
+}
+
+func (f *Client) GetTlsCert(tlsCertId string) (*dtos.TLSCertResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	cert, ok := f.tlsCerts[tlsCertId]
+	if !ok {
+		return nil, fmt.Errorf("TLS certificate %s: %w", tlsCertId, errNotFound)
+	}
+
+	return clone(cert), nil
+}
+
+func (f *Client) UploadTlsCert(req dtos.UploadTlsCertRequest) (*dtos.TLSCertResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.environments[req.EnvironmentID]; !ok {
+		return nil, fmt.Errorf("environment %s: %w", req.EnvironmentID, errNotFound)
+	}
+
+	block, _ := pem.Decode([]byte(req.PrimaryCert))
+	if block == nil {
+		return nil, fmt.Errorf("primary_cert is not a PEM certificate: %w", errBadRequest)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid primary_cert: %s: %w", err, errBadRequest)
+	}
+
+	now := fakeNow().Format(time.RFC3339)
+	cert := &dtos.TLSCertResponse{
+		ID:               f.newID("cert"),
+		EnvironmentID:    req.EnvironmentID,
+		PrimaryCert:      req.PrimaryCert,
+		IntermediateCert: req.IntermediateCert,
+		Expiration:       certificate.NotAfter.UTC().Format(time.RFC3339),
+		Status:           "created",
+		Serial:           certificate.SerialNumber.String(),
+		CommonName:       certificate.Subject.CommonName,
+		AlternativeNames: append([]string{}, certificate.DNSNames...),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	f.tlsCerts[cert.ID] = cert
+
+	return clone(cert), nil
+}
+
+func (f *Client) GenerateTlsCert(environmentId string) (*dtos.TLSCertResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	environment, ok := f.environments[environmentId]
+	if !ok {
+		return nil, fmt.Errorf("environment %s: %w", environmentId, errNotFound)
+	}
+
+	now := fakeNow().Format(time.RFC3339)
+	cert := &dtos.TLSCertResponse{
+		ID:               f.newID("cert"),
+		EnvironmentID:    environmentId,
+		Status:           "processing",
+		Generated:        true,
+		CommonName:       environment.DefaultDomainName,
+		AlternativeNames: []string{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	f.tlsCerts[cert.ID] = cert
+
+	return clone(cert), nil
+}
+
+func (f *Client) GetTlsCerts(page int, pageSize int, environmentID string) (*dtos.TLSCertSResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var items []dtos.TLSCertResponse
+	for _, cert := range sortedValues(f.tlsCerts) {
+		if cert.EnvironmentID == environmentID {
+			items = append(items, *clone(cert))
+		}
+	}
+
+	return &dtos.TLSCertSResponse{
+		EnvironmentID: environmentID,
+		TotalItems:    int32(len(items)),
+		Certificates:  paginate(items, page, pageSize),
+	}, nil
+}
+
+func (f *Client) DeleteTlsCert(tlsCertID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.tlsCerts[tlsCertID]; !ok {
+		return fmt.Errorf("TLS certificate %s: %w", tlsCertID, errNotFound)
+	}
+
+	delete(f.tlsCerts, tlsCertID)
+	return nil
+}
+
+func (f *Client) UploadCdnConfiguration(config *dtos.CDNConfiguration) (*dtos.CDNConfiguration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.environments[config.EnvironmentID]; !ok {
+		return nil, fmt.Errorf("environment %s: %w", config.EnvironmentID, errNotFound)
+	}
+
+	uploaded := clone(config)
+	uploaded.ConfigurationID = f.newID("config")
+	f.configs[uploaded.ConfigurationID] = uploaded
+	f.activeConfigs[uploaded.EnvironmentID] = uploaded.ConfigurationID
+
+	return clone(uploaded), nil
+}
+
+func (f *Client) GetCDNConfiguration(configID string) (*dtos.CDNConfiguration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	config, ok := f.configs[configID]
+	if !ok {
+		return nil, fmt.Errorf("configuration %s: %w", configID, errNotFound)
+	}
+
+	return clone(config), nil
+}
+
+func (f *Client) GetEnvironmentCDNConfiguration(environmentID string) (*dtos.CDNConfiguration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	configID, ok := f.activeConfigs[environmentID]
+	if !ok {
+		return nil, fmt.Errorf("configuration of environment %s: %w", environmentID, errNotFound)
+	}
+
+	return clone(f.configs[configID]), nil
+}
+
+// fakeNow returns the current time at the precision of the API.
+func fakeNow() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// clone deep copies objects, so callers cannot modify the stored ones.
+func clone[T any](value *T) *T {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		panic(err)
+	}
+	return &copied
+}
+
+func hideSecret(variable *dtos.EnvironmentVariable) *dtos.EnvironmentVariable {
+	copied := clone(variable)
+	if copied.Secret {
+		copied.Value = ""
+	}
+	return copied
+}
+
+// sortedValues returns the values ordered by ID, which is the creation order.
+func sortedValues[T any](objects map[string]*T) []*T {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	values := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, objects[id])
+	}
+	return values
+}
+
+// paginate returns the items of a page. Pages start at 1 like in the API,
+// page 0 is treated as the first page and a page size below 1 returns all
+// items.
+func paginate[T any](items []T, page, pageSize int) []T {
+	if pageSize < 1 {
+		return append([]T{}, items...)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * pageSize
+	if start >= len(items) {
+		return []T{}
+	}
+
+	end := min(start+pageSize, len(items))
+	return append([]T{}, items[start:end]...)
+}
@@ -0,0 +1,235 @@
+package edgiofake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+)
+
+// AccessToken is the access token issued by the fake server's token
+// endpoint.
+const AccessToken = "fake-access-token"
+
+// NewServer starts an HTTP server speaking the Edgio API endpoints used by
+// edgio_api.EdgioClient, backed by fake. The token endpoint is served at
+// /connect/token, so a real client can be pointed at it with
+//
+//	edgio_api.NewEdgioClient(credentials, edgio_api.TransportOptions{}, server.URL+"/connect/token", server.URL)
+//
+// API requests without a bearer token are rejected with 401. The caller
+// must close the server.
+func NewServer(fake *Client) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /connect/token", fakeHandler(func(r *http.Request) (any, error) {
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("%s: %w", err, errBadRequest)
+		}
+
+		return edgio_api.AccessTokenResponse{
+			AccessToken: AccessToken,
+			ExpiresIn:   3600,
+			TokenType:   "Bearer",
+			Scope:       r.PostForm.Get("scope"),
+		}, nil
+	}))
+
+	api := http.NewServeMux()
+
+	api.HandleFunc("GET /accounts/v0.1/properties/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetProperty(r.Context(), r.PathValue("id"))
+	}))
+	api.HandleFunc("GET /accounts/v0.1/properties", fakeHandler(func(r *http.Request) (any, error) {
+		page, pageSize := fakePage(r)
+		return fake.GetProperties(page, pageSize, r.URL.Query().Get("organization_id"))
+	}))
+	api.HandleFunc("POST /accounts/v0.1/properties", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			OrganizationID string `json:"organization_id"`
+			Slug           string `json:"slug"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.CreateProperty(r.Context(), body.OrganizationID, body.Slug)
+	}))
+	api.HandleFunc("PATCH /accounts/v0.1/properties/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			Slug string `json:"slug"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.UpdateProperty(r.Context(), r.PathValue("id"), body.Slug)
+	}))
+	api.HandleFunc("DELETE /accounts/v0.1/properties/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return nil, fake.DeleteProperty(r.PathValue("id"))
+	}))
+
+	api.HandleFunc("GET /accounts/v0.1/environments", fakeHandler(func(r *http.Request) (any, error) {
+		page, pageSize := fakePage(r)
+		return fake.GetEnvironments(page, pageSize, r.URL.Query().Get("property_id"))
+	}))
+	api.HandleFunc("GET /accounts/v0.1/environments/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetEnvironment(r.PathValue("id"))
+	}))
+	api.HandleFunc("POST /accounts/v0.1/environments", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			PropertyID               string `json:"property_id"`
+			Name                     string `json:"name"`
+			OnlyMaintainersCanDeploy bool   `json:"only_maintainers_can_deploy"`
+			HttpRequestLogging       bool   `json:"http_request_logging"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.CreateEnvironment(body.PropertyID, body.Name, body.OnlyMaintainersCanDeploy, body.HttpRequestLogging)
+	}))
+	api.HandleFunc("PATCH /accounts/v0.1/environments/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			Name                     string `json:"name"`
+			OnlyMaintainersCanDeploy bool   `json:"only_maintainers_can_deploy"`
+			HttpRequestLogging       bool   `json:"http_request_logging"`
+			PreserveCache            bool   `json:"preserve_cache"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.UpdateEnvironment(r.PathValue("id"), body.Name, body.OnlyMaintainersCanDeploy, body.HttpRequestLogging, body.PreserveCache)
+	}))
+	api.HandleFunc("DELETE /accounts/v0.1/environments/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return nil, fake.DeleteEnvironment(r.PathValue("id"))
+	}))
+
+	api.HandleFunc("GET /config/v0.1/environment-variables/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetEnvironmentVariable(r.PathValue("id"))
+	}))
+	api.HandleFunc("POST /config/v0.1/environment-variables", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			EnvironmentID string `json:"environment_id"`
+			Key           string `json:"key"`
+			Value         string `json:"value"`
+			Secret        bool   `json:"secret"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.CreateEnvironmentVariable(body.EnvironmentID, body.Key, body.Value, body.Secret)
+	}))
+	api.HandleFunc("PATCH /config/v0.1/environment-variables/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			Key    string `json:"key"`
+			Value  string `json:"value"`
+			Secret bool   `json:"secret"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.UpdateEnvironmentVariable(r.PathValue("id"), body.Key, body.Value, body.Secret)
+	}))
+	api.HandleFunc("DELETE /config/v0.1/environment-variables/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return nil, fake.DeleteEnvironmentVariable(r.PathValue("id"))
+	}))
+
+	api.HandleFunc("GET /config/v0.1/tls-certs/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetTlsCert(r.PathValue("id"))
+	}))
+	api.HandleFunc("GET /config/v0.1/tls-certs", fakeHandler(func(r *http.Request) (any, error) {
+		page, pageSize := fakePage(r)
+		return fake.GetTlsCerts(page, pageSize, r.URL.Query().Get("environment_id"))
+	}))
+	api.HandleFunc("POST /config/v0.1/tls-certs", fakeHandler(func(r *http.Request) (any, error) {
+		var body dtos.UploadTlsCertRequest
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.UploadTlsCert(body)
+	}))
+	api.HandleFunc("POST /config/v0.1/tls-certs/generate", fakeHandler(func(r *http.Request) (any, error) {
+		var body struct {
+			EnvironmentID string `json:"environment_id"`
+		}
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.GenerateTlsCert(body.EnvironmentID)
+	}))
+	api.HandleFunc("DELETE /config/v0.1/tls-certs/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return nil, fake.DeleteTlsCert(r.PathValue("id"))
+	}))
+
+	api.HandleFunc("POST /config/v0.1/configs", fakeHandler(func(r *http.Request) (any, error) {
+		var body dtos.CDNConfiguration
+		if err := readFakeBody(r, &body); err != nil {
+			return nil, err
+		}
+		return fake.UploadCdnConfiguration(&body)
+	}))
+	api.HandleFunc("GET /config/v0.1/configs/{id}", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetCDNConfiguration(r.PathValue("id"))
+	}))
+	api.HandleFunc("GET /config/v0.1/configs", fakeHandler(func(r *http.Request) (any, error) {
+		return fake.GetEnvironmentCDNConfiguration(r.URL.Query().Get("environment_id"))
+	}))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeFakeError(w, http.StatusUnauthorized, errors.New("missing bearer token"))
+			return
+		}
+
+		api.ServeHTTP(w, r)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func fakePage(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	return page, pageSize
+}
+
+func readFakeBody(r *http.Request, body any) error {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		return fmt.Errorf("invalid request body: %s: %w", err, errBadRequest)
+	}
+	return nil
+}
+
+// fakeHandler writes the result of handle as JSON, errors as problem
+// details and a nil result as 204 No Content.
+func fakeHandler(handle func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		value, err := handle(r)
+		switch {
+		case errors.Is(err, errNotFound):
+			writeFakeError(w, http.StatusNotFound, err)
+		case err != nil:
+			writeFakeError(w, http.StatusBadRequest, err)
+		case value == nil:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(value)
+		}
+	}
+}
+
+// writeFakeError writes errors as problem details like the API does.
+func writeFakeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": err.Error(),
+	})
+}
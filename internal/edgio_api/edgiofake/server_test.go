@@ -0,0 +1,116 @@
+package edgiofake_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+)
+
+func newFakeServerClient(t *testing.T) (*edgiofake.Client, *edgio_api.EdgioClient) {
+	fake := edgiofake.NewClient()
+	server := edgiofake.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := edgio_api.NewEdgioClient(
+		edgio_api.Credentials{ClientID: "client-id", ClientSecret: "client-secret"},
+		edgio_api.TransportOptions{},
+		server.URL+"/connect/token",
+		server.URL,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fake, client
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	fake, client := newFakeServerClient(t)
+	ctx := context.Background()
+
+	property, err := client.CreateProperty(ctx, "org-123", "my-property")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"production", "staging", "development"} {
+		if _, err := client.CreateEnvironment(property.Id, name, false, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := client.GetEnvironments(2, 2, property.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalItems != 3 || len(page.Items) != 1 || page.Items[0].Name != "development" {
+		t.Errorf("unexpected second page: %+v", page)
+	}
+
+	environment := page.Items[0]
+	variable, err := client.CreateEnvironmentVariable(environment.Id, "TOKEN", "secret-value", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variable.Value != "" {
+		t.Errorf("secret value returned: %q", variable.Value)
+	}
+	if _, err := client.GenerateTlsCert(environment.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteProperty(property.Id); err == nil {
+		t.Error("expected property with environments not to be deleted")
+	}
+
+	if err := client.DeleteEnvironment(environment.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.GetEnvironmentVariable(variable.Id); err == nil {
+		t.Error("expected environment variable to be deleted with its environment")
+	}
+	certs, err := client.GetTlsCerts(1, 10, environment.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certs.TotalItems != 0 {
+		t.Errorf("expected TLS certificates to be deleted with their environment, got %d", certs.TotalItems)
+	}
+}
+
+func TestServer_NotFound(t *testing.T) {
+	_, client := newFakeServerClient(t)
+
+	_, err := client.GetProperty(context.Background(), "property-unknown")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	if err := client.DeleteEnvironment("env-unknown"); err == nil {
+		t.Error("expected unknown environment not to be deleted")
+	}
+
+	if _, err := client.GetEnvironmentCDNConfiguration("env-unknown"); !errors.Is(err, edgio_api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an environment without configuration, got: %v", err)
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := edgiofake.NewServer(edgiofake.NewClient())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/accounts/v0.1/properties?organization_id=org-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,47 @@
+package edgio_api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-uuid"
+)
+
+// requestIDHeader carries a unique ID of every request, which Edgio support
+// can use to find the request in their logs.
+const requestIDHeader = "X-Request-ID"
+
+// configureRequestHeaders sets the User-Agent of all requests, generates an
+// X-Request-ID for every attempt and logs it together with the response.
+func configureRequestHeaders(client *resty.Client, userAgent string) {
+	if userAgent != "" {
+		client.SetHeader("User-Agent", userAgent)
+	}
+
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return fmt.Errorf("failed to generate request ID: %w", err)
+		}
+
+		req.SetHeader(requestIDHeader, id)
+		return nil
+	})
+
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		log.Printf("[DEBUG] Edgio API %s %s responded with status %d, request ID %s",
+			resp.Request.Method, resp.Request.URL, resp.StatusCode(), requestID(resp))
+		return nil
+	})
+
+	client.OnError(func(req *resty.Request, err error) {
+		log.Printf("[DEBUG] Edgio API %s %s failed, request ID %s: %s",
+			req.Method, req.URL, req.Header.Get(requestIDHeader), err)
+	})
+}
+
+// requestID returns the X-Request-ID of the request of the response.
+func requestID(resp *resty.Response) string {
+	return resp.Request.Header.Get(requestIDHeader)
+}
@@ -0,0 +1,79 @@
+package edgio_api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaultTimeout is the timeout of API and token requests if
+// TransportOptions.Timeout is not set.
+const defaultTimeout = 30 * time.Second
+
+// TransportOptions configure the HTTP transport used for API and token
+// requests. The zero value uses the proxy of the environment, the system
+// CA certificates, the default timeout and the User-Agent of resty.
+type TransportOptions struct {
+	UserAgent          string
+	ProxyURL           string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	Timeout            time.Duration
+	InsecureSkipVerify bool
+}
+
+// configureTransport applies the transport options to the resty client.
+func configureTransport(client *resty.Client, options TransportOptions) error {
+	configureRequestHeaders(client, options.UserAgent)
+
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	client.SetTimeout(timeout)
+
+	if options.ProxyURL != "" {
+		// SetProxy only logs invalid URLs.
+		if _, err := url.Parse(options.ProxyURL); err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		client.SetProxy(options.ProxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	if options.CACertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(options.CACertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in %s", options.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if options.ClientCertFile != "" || options.ClientKeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(options.ClientCertFile, options.ClientKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	client.SetTLSClientConfig(tlsConfig)
+	return nil
+}
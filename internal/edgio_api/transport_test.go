@@ -0,0 +1,120 @@
+package edgio_api_test
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+)
+
+func environmentHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"id":"env-123"}`)
+}
+
+func TestEdgioClient_CACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(environmentHandler))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Untrusted certificates are not tested, as failed requests are retried
+	// for up to a minute.
+	credentials := edgio_api.Credentials{AccessToken: "token"}
+	for name, transport := range map[string]edgio_api.TransportOptions{
+		"ca_cert_file":         {CACertFile: caFile},
+		"insecure_skip_verify": {InsecureSkipVerify: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, err := edgio_api.NewEdgioClient(credentials, transport, "", server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.GetEnvironment("env-123"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEdgioClient_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		environmentHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	client, err := edgio_api.NewEdgioClient(
+		edgio_api.Credentials{AccessToken: "token"},
+		edgio_api.TransportOptions{ProxyURL: proxy.URL},
+		"",
+		"http://api.edgio.test",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetEnvironment("env-123"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(proxied) != 1 || proxied[0] != "http://api.edgio.test/accounts/v0.1/environments/env-123" {
+		t.Errorf("expected the request to go through the proxy, got %v", proxied)
+	}
+}
+
+func TestEdgioClient_InvalidCACertFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := edgio_api.NewEdgioClient(edgio_api.Credentials{}, edgio_api.TransportOptions{CACertFile: caFile}, "", "")
+	if err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+}
+
+func TestEdgioClient_RequestHeaders(t *testing.T) {
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "terraform-provider-edgio/1.2.3 terraform/1.11.4" {
+			t.Errorf("unexpected User-Agent %s", r.Header.Get("User-Agent"))
+		}
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := edgio_api.NewEdgioClient(
+		edgio_api.Credentials{AccessToken: "token"},
+		edgio_api.TransportOptions{UserAgent: "terraform-provider-edgio/1.2.3 terraform/1.11.4"},
+		"",
+		server.URL,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetEnvironment("env-123")
+	_, _ = client.GetEnvironment("env-123")
+
+	if len(requestIDs) != 2 || requestIDs[0] == "" || requestIDs[0] == requestIDs[1] {
+		t.Fatalf("expected a new request ID for every request, got %v", requestIDs)
+	}
+	if err == nil || !strings.Contains(err.Error(), requestIDs[0]) {
+		t.Errorf("expected the error to quote request ID %s, got %v", requestIDs[0], err)
+	}
+}
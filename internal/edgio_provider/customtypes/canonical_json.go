@@ -0,0 +1,82 @@
+package customtypes
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// CanonicalJSON returns the canonical form of a JSON document: no insignificant
+// whitespace, object keys in sorted order and numbers in their shortest form,
+// so that e.g. 1.0, 1e0 and 1 all normalise to 1.
+func CanonicalJSON(jsonStr string) (string, error) {
+	obj, err := DecodeJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	canonical, err := json.Marshal(CanonicalizeJSON(obj))
+	if err != nil {
+		return "", err
+	}
+
+	return string(canonical), nil
+}
+
+// DecodeJSON decodes numbers as json.Number so that large integers survive
+// the round trip unchanged.
+func DecodeJSON(jsonStr string) (interface{}, error) {
+	var obj interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(jsonStr))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// CanonicalizeJSON rewrites the numbers of a decoded JSON document in place to
+// their canonical form. Key order is canonical once the document is marshalled.
+func CanonicalizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = CanonicalizeJSON(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = CanonicalizeJSON(item)
+		}
+	case json.Number:
+		return canonicalNumber(v)
+	}
+
+	return value
+}
+
+func canonicalNumber(number json.Number) json.Number {
+	// Integers are already canonical, including those beyond the float64 range
+	// of exact integers.
+	if integer, ok := new(big.Int).SetString(number.String(), 10); ok {
+		return json.Number(integer.String())
+	}
+
+	f, err := number.Float64()
+	if err != nil || math.IsInf(f, 0) {
+		return number
+	}
+
+	if f == 0 {
+		return json.Number("0")
+	}
+
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+	}
+
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
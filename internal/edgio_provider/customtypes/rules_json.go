@@ -0,0 +1,119 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = RulesJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = RulesJSON{}
+)
+
+// RulesJSONType is the type of the CDN rules JSON. Values that only differ in
+// whitespace, key order or number notation are semantically equal, so the API
+// returning the rules in another form does not show up as drift.
+type RulesJSONType struct {
+	basetypes.StringType
+}
+
+func (t RulesJSONType) String() string {
+	return "customtypes.RulesJSONType"
+}
+
+func (t RulesJSONType) ValueType(ctx context.Context) attr.Value {
+	return RulesJSON{}
+}
+
+func (t RulesJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(RulesJSONType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t RulesJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return RulesJSON{StringValue: in}, nil
+}
+
+func (t RulesJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return RulesJSON{StringValue: stringValue}, nil
+}
+
+// RulesJSON is a value of RulesJSONType.
+type RulesJSON struct {
+	basetypes.StringValue
+}
+
+func NewRulesJSONValue(value string) RulesJSON {
+	return RulesJSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewRulesJSONNull() RulesJSON {
+	return RulesJSON{StringValue: basetypes.NewStringNull()}
+}
+
+func NewRulesJSONUnknown() RulesJSON {
+	return RulesJSON{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v RulesJSON) Type(ctx context.Context) attr.Type {
+	return RulesJSONType{}
+}
+
+func (v RulesJSON) Equal(o attr.Value) bool {
+	other, ok := o.(RulesJSON)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares the canonical forms of both values. Values that
+// are not valid JSON are only equal if they are identical.
+func (v RulesJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(RulesJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. This is always an error in the provider.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, diags
+	}
+
+	prior, err := CanonicalJSON(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	updated, err := CanonicalJSON(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return prior == updated, diags
+}
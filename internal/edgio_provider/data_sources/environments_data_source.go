@@ -13,30 +13,38 @@ import (
 )
 
 type EnvironmentsDataSource struct {
-	client edgio_api.EdgioClientInterface
+	client            edgio_api.EdgioClientInterface
+	defaultPropertyID types.String
 }
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource = &EnvironmentsDataSource{}
+	_ datasource.DataSource              = &EnvironmentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &EnvironmentsDataSource{}
 )
 
-func NewEnvironmentsDataSource(client edgio_api.EdgioClientInterface) *EnvironmentsDataSource {
-	return &EnvironmentsDataSource{
-		client: client,
-	}
+func NewEnvironmentsDataSource() datasource.DataSource {
+	return &EnvironmentsDataSource{}
 }
 
 func (d *EnvironmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = "edgio_environments"
 }
 
+func (d *EnvironmentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		d.client = data.Client
+		d.defaultPropertyID = data.DefaultPropertyID
+	}
+}
+
 func (d *EnvironmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"property_id": schema.StringAttribute{
-				Required:    true,
-				Description: `The ID of the property to filter environments by.`,
+				Optional:    true,
+				Computed:    true,
+				Description: `The ID of the property to filter environments by. Defaults to the default_property_id of the provider.`,
 			},
 			"item_count": schema.Int32Attribute{
 				Computed:    true,
@@ -98,7 +106,11 @@ func (d *EnvironmentsDataSource) Schema(ctx context.Context, req datasource.Sche
 }
 
 func (d *EnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var propertyID string
+	if !models.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var propertyID types.String
 	diags := req.Config.GetAttribute(ctx, path.Root("property_id"), &propertyID)
 	resp.Diagnostics.Append(diags...)
 
@@ -106,14 +118,26 @@ func (d *EnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	environments, err := d.client.GetEnvironments(1, 10, propertyID)
+	if propertyID.IsNull() {
+		propertyID = d.defaultPropertyID
+	}
+	if propertyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("property_id"),
+			"Missing Attribute",
+			"'property_id' must be set either on the data source or as 'default_property_id' on the provider.",
+		)
+		return
+	}
+
+	environments, err := d.client.GetEnvironments(1, 10, propertyID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading environments", err.Error())
 		return
 	}
 
 	state := models.EnvironmentsModel{
-		Id:           types.StringValue(propertyID),
+		PropertyID:   propertyID,
 		TotalItems:   types.Int32Value(int32(environments.TotalItems)),
 		Environments: []models.EnvironmentModel{},
 	}
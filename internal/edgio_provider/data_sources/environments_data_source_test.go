@@ -0,0 +1,50 @@
+package data_sources_test
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestEnvironmentsDataSource(t *testing.T) {
+	fake := edgiofake.NewClient()
+	property, err := fake.CreateProperty(context.Background(), "org-123", "example-slug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"production", "staging"} {
+		if _, err := fake.CreateEnvironment(property.Id, name, false, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(fake)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id           = "mock-client-id"
+					client_secret       = "mock-client-secret"
+					default_property_id = "` + property.Id + `"
+				}
+
+				data "edgio_environments" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgio_environments.test", "property_id", property.Id),
+					resource.TestCheckResourceAttr("data.edgio_environments.test", "item_count", "2"),
+					resource.TestCheckResourceAttr("data.edgio_environments.test", "environments.0.name", "production"),
+					resource.TestCheckResourceAttr("data.edgio_environments.test", "environments.1.name", "staging"),
+				),
+			},
+		},
+	})
+}
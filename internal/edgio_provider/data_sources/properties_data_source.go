@@ -9,36 +9,45 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type PropertiesDataSource struct {
-	client edgio_api.EdgioClientInterface
+	client         edgio_api.EdgioClientInterface
+	organizationID types.String
 }
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource = &PropertiesDataSource{}
+	_ datasource.DataSource              = &PropertiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &PropertiesDataSource{}
 )
 
-func NewPropertiesDataSource(client edgio_api.EdgioClientInterface) *PropertiesDataSource {
-	return &PropertiesDataSource{
-		client: client,
-	}
+func NewPropertiesDataSource() datasource.DataSource {
+	return &PropertiesDataSource{}
 }
 
 func (d *PropertiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = "edgio_properties"
 }
 
+func (d *PropertiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		d.client = data.Client
+		d.organizationID = data.OrganizationID
+	}
+}
+
 func (d *PropertiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
 				Description: `An organization's system-defined ID (e.g., 12345678-1234-1234-1234-1234567890ab).
 					 From the Edgio Console, navigate to the desired organization and then click Settings. 
-					 It is listed under Organization ID."`,
+					 It is listed under Organization ID. Defaults to the organization_id of the provider."`,
 			},
 			"item_count": schema.Int32Attribute{
 				Required:    true,
@@ -82,6 +91,10 @@ func (d *PropertiesDataSource) Schema(ctx context.Context, req datasource.Schema
 }
 
 func (d *PropertiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !models.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state models.PropertiesModel
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -89,7 +102,15 @@ func (d *PropertiesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	if state.OrganizationID.IsNull() {
+		state.OrganizationID = d.organizationID
+	}
+	if state.OrganizationID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Missing Attribute",
+			"'organization_id' must be set either on the data source or on the provider.",
+		)
 		return
 	}
 
@@ -0,0 +1,89 @@
+package data_sources
+
+import (
+	"context"
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderInfoDataSource returns the provider version, the API it talks to and
+// the scopes it authenticated for, to help debugging the provider setup.
+type ProviderInfoDataSource struct {
+	client  edgio_api.EdgioClientInterface
+	version string
+	apiURL  string
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ProviderInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProviderInfoDataSource{}
+)
+
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &ProviderInfoDataSource{}
+}
+
+func (d *ProviderInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		d.client = data.Client
+		d.version = data.Version
+		d.apiURL = data.APIURL
+	}
+}
+
+func (d *ProviderInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "edgio_provider_info"
+}
+
+func (d *ProviderInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Information about the configured provider.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The version of the provider.",
+			},
+			"api_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the Edgio API.",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The scopes the provider obtained access tokens for. Resources require `app.accounts` and `app.config`. With `access_token` or `access_token_file` the scopes are read from the `scope` claim of the token without verifying it, and are null if the token is no JWT or has no `scope` claim.",
+			},
+		},
+	}
+}
+
+func (d *ProviderInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !models.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	scopes, err := d.client.AuthenticatedScopes()
+	if err != nil {
+		resp.Diagnostics.AddError("Error authenticating", err.Error())
+		return
+	}
+
+	scopesValue, diags := types.ListValueFrom(ctx, types.StringType, scopes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := models.ProviderInfoModel{
+		Version: types.StringValue(d.version),
+		APIURL:  types.StringValue(d.apiURL),
+		Scopes:  scopesValue,
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,40 @@
+package data_sources_test
+
+import (
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestProviderInfoDataSource(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+	mockClient.On("AuthenticatedScopes").Return([]string{"app.accounts"}, nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				data "edgio_provider_info" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgio_provider_info.test", "version", "test"),
+					resource.TestCheckResourceAttr("data.edgio_provider_info.test", "api_url", "https://edgioapis.com"),
+					resource.TestCheckResourceAttr("data.edgio_provider_info.test", "scopes.#", "1"),
+					resource.TestCheckResourceAttr("data.edgio_provider_info.test", "scopes.0", "app.accounts"),
+				),
+			},
+		},
+	})
+}
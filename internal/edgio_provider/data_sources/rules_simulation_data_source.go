@@ -0,0 +1,159 @@
+package data_sources
+
+import (
+	"context"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RulesSimulationDataSource evaluates CDN rules locally, it never calls the
+// Edgio API.
+type RulesSimulationDataSource struct{}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &RulesSimulationDataSource{}
+)
+
+func NewRulesSimulationDataSource() datasource.DataSource {
+	return &RulesSimulationDataSource{}
+}
+
+func (d *RulesSimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "edgio_rules_simulation"
+}
+
+func (d *RulesSimulationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates CDN rules against a synthetic request without deploying them.",
+		Attributes: map[string]schema.Attribute{
+			"rules": schema.StringAttribute{
+				Required:    true,
+				Description: "The CDN rules JSON, e.g. the `rules` attribute of an `edgio_cdn_configuration`.",
+			},
+			"request": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The request to evaluate the rules against.",
+				Attributes: map[string]schema.Attribute{
+					"method": schema.StringAttribute{
+						Optional:    true,
+						Description: "The request method. Defaults to `GET`.",
+					},
+					"scheme": schema.StringAttribute{
+						Optional:    true,
+						Description: "The request scheme. Defaults to `https`.",
+					},
+					"host": schema.StringAttribute{
+						Optional:    true,
+						Description: "The request host, used for the `host` header unless set in `headers`.",
+					},
+					"path": schema.StringAttribute{
+						Required:    true,
+						Description: "The request path, without the query string.",
+					},
+					"query": schema.StringAttribute{
+						Optional:    true,
+						Description: "The query string, without the leading `?`.",
+					},
+					"headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "The request headers. Header names are matched case insensitively.",
+					},
+					"cookies": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "The request cookies.",
+					},
+					"country": schema.StringAttribute{
+						Optional:    true,
+						Description: "The two-letter country code of the client.",
+					},
+				},
+			},
+			"matched_rules": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "The zero-based indices of the rules that apply to the request.",
+			},
+			"features": schema.StringAttribute{
+				Computed:    true,
+				Description: "The features of all matched rules as JSON, merged in rule order.",
+			},
+			"origin": schema.StringAttribute{
+				Computed:    true,
+				Description: "The origin set by the matched rules.",
+			},
+			"max_age": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The edge cache TTL by response status code.",
+			},
+			"bypass_cache": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the edge cache is bypassed.",
+			},
+			"request_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The request headers set by the matched rules.",
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The response headers set or added by the matched rules.",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The response status code set by the matched rules.",
+			},
+			"rewritten_path": schema.StringAttribute{
+				Computed:    true,
+				Description: "The path after applying the first matching URL rewrite.",
+			},
+			"redirect_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The status code of the redirect, if the request is redirected.",
+			},
+			"redirect_location": schema.StringAttribute{
+				Computed:    true,
+				Description: "The location the request is redirected to, if any.",
+			},
+			"edge_function": schema.StringAttribute{
+				Computed:    true,
+				Description: "The edge function that handles the request.",
+			},
+		},
+	}
+}
+
+func (d *RulesSimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.RulesSimulationModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := utility.ConvertSimulatedRequestToNative(state.Request)
+
+	simulation, err := utility.SimulateRules(state.Rules.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rules"), "Error simulating rules", err.Error())
+		return
+	}
+
+	if err := utility.ConvertRulesSimulationToModel(simulation, request, &state); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rules"), "Error simulating rules", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,80 @@
+package data_sources_test
+
+import (
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRulesSimulationDataSource(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				locals {
+					rules = jsonencode([
+						{
+							"if" : [
+								{ "==" : [{ "request" : "path" }, "/api/:path*"] },
+								{ "caching" : { "max_age" : { "200" : "1h" } }, "origin" : { "set_origin" : "api" } },
+								{ "else" : { "caching" : { "bypass_cache" : true } } }
+							]
+						},
+						{
+							"if" : [
+								{ "==" : [{ "request.header" : "host" }, "old.example.com"] },
+								{ "url" : { "url_redirect" : { "code" : 302, "source" : "/:path*", "destination" : "https://new.example.com/:path*" } } }
+							]
+						},
+						{ "headers" : { "set_response_headers" : { "x-powered-by" : "edgio" } } }
+					])
+				}
+
+				data "edgio_rules_simulation" "api" {
+					rules = local.rules
+					request = {
+						host = "old.example.com"
+						path = "/api/users/1"
+					}
+				}
+
+				data "edgio_rules_simulation" "static" {
+					rules = local.rules
+					request = {
+						method = "POST"
+						host   = "www.example.com"
+						path   = "/index.html"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "matched_rules.#", "3"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "origin", "api"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "max_age.200", "1h"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "redirect_code", "302"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "redirect_location", "https://new.example.com/api/users/1"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.api", "response_headers.x-powered-by", "edgio"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.static", "matched_rules.#", "2"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.static", "matched_rules.0", "0"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.static", "matched_rules.1", "2"),
+					resource.TestCheckResourceAttr("data.edgio_rules_simulation.static", "bypass_cache", "true"),
+					resource.TestCheckNoResourceAttr("data.edgio_rules_simulation.static", "origin"),
+				),
+			},
+		},
+	})
+}
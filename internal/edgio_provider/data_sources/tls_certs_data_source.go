@@ -18,12 +18,17 @@ type TlsCertsDataSource struct {
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource = &TlsCertsDataSource{}
+	_ datasource.DataSource              = &TlsCertsDataSource{}
+	_ datasource.DataSourceWithConfigure = &TlsCertsDataSource{}
 )
 
-func NewTlsCertsDataSource(client edgio_api.EdgioClientInterface) *TlsCertsDataSource {
-	return &TlsCertsDataSource{
-		client: client,
+func NewTlsCertsDataSource() datasource.DataSource {
+	return &TlsCertsDataSource{}
+}
+
+func (d *TlsCertsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		d.client = data.Client
 	}
 }
 
@@ -111,6 +116,10 @@ func (d *TlsCertsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 }
 
 func (d *TlsCertsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !models.RequireClient(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var environmentID string
 	var item_count int32
 	diags := req.Config.GetAttribute(ctx, path.Root("environment_id"), &environmentID)
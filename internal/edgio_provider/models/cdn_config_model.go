@@ -1,10 +1,33 @@
 package models
 
 import (
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type CDNConfigurationModel struct {
+	ConfigurationID            types.String             `tfsdk:"configuration_id"`
+	EnvironmentID              types.String             `tfsdk:"environment_id"`
+	Rules                      customtypes.RulesJSON    `tfsdk:"rules"`
+	Rule                       []RuleModel              `tfsdk:"rule"`
+	Origins                    []OriginModel            `tfsdk:"origins"`
+	OriginsByName              map[string]OriginModel   `tfsdk:"origins_by_name"`
+	Hostnames                  []HostnameModel          `tfsdk:"hostnames"`
+	HostnamesByName            map[string]HostnameModel `tfsdk:"hostnames_by_name"`
+	Experiments                types.List               `tfsdk:"experiments"`
+	EdgeFunctionsSources       types.Map                `tfsdk:"edge_functions_sources"`
+	EdgeFunctionInitScript     types.String             `tfsdk:"edge_function_init_script"`
+	EdgeFunctionsDirectory     types.String             `tfsdk:"edge_functions_directory"`
+	EdgeFunctionInitScriptFile types.String             `tfsdk:"edge_function_init_script_file"`
+	EdgeFunctionsHash          types.String             `tfsdk:"edge_functions_hash"`
+	RulesLint                  types.String             `tfsdk:"rules_lint"`
+}
+
+// CDNConfigurationModelV0 is the state of edgio_cdn_configuration before origins
+// and hostnames could be keyed by name.
+type CDNConfigurationModelV0 struct {
 	ConfigurationID        types.String    `tfsdk:"configuration_id"`
 	EnvironmentID          types.String    `tfsdk:"environment_id"`
 	Rules                  types.String    `tfsdk:"rules"`
@@ -73,10 +96,12 @@ type HostnameModel struct {
 	Hostname          types.String `tfsdk:"hostname"`
 	DefaultOriginName types.String `tfsdk:"default_origin_name"`
 	ReportCode        types.Int64  `tfsdk:"report_code"`
-	TLS               *TLSModel    `tfsdk:"tls"`
+	TLS               types.Object `tfsdk:"tls"`
 	Directory         types.String `tfsdk:"directory"`
 }
 
+// TLSModel is the content of HostnameModel.TLS. The TLS settings are held as an
+// object so that they can be unknown until the API has filled in its defaults.
 type TLSModel struct {
 	NPN                 types.Bool   `tfsdk:"npn"`
 	ALPN                types.Bool   `tfsdk:"alpn"`
@@ -93,3 +118,21 @@ type TLSModel struct {
 	PEM                 types.String `tfsdk:"pem"`
 	CA                  types.String `tfsdk:"ca"`
 }
+
+// TLSAttributeTypes are the attribute types of the TLSModel object.
+var TLSAttributeTypes = map[string]attr.Type{
+	"npn":                  types.BoolType,
+	"alpn":                 types.BoolType,
+	"protocols":            types.StringType,
+	"use_sigalgs":          types.BoolType,
+	"sni":                  types.BoolType,
+	"sni_strict":           types.BoolType,
+	"sni_host_match":       types.BoolType,
+	"client_renegotiation": types.BoolType,
+	"options":              types.StringType,
+	"cipher_list":          types.StringType,
+	"named_curve":          types.StringType,
+	"oscp":                 types.BoolType,
+	"pem":                  types.StringType,
+	"ca":                   types.StringType,
+}
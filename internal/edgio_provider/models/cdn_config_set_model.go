@@ -0,0 +1,31 @@
+package models
+
+import (
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CDNConfigurationSetModel is a base CDN configuration that is uploaded to
+// several environments, each with its own overrides.
+type CDNConfigurationSetModel struct {
+	ID                     types.String                       `tfsdk:"id"`
+	Rules                  customtypes.RulesJSON              `tfsdk:"rules"`
+	RulesLint              types.String                       `tfsdk:"rules_lint"`
+	Origins                []OriginModel                      `tfsdk:"origins"`
+	Hostnames              []HostnameModel                    `tfsdk:"hostnames"`
+	Experiments            types.List                         `tfsdk:"experiments"`
+	EdgeFunctionsSources   types.Map                          `tfsdk:"edge_functions_sources"`
+	EdgeFunctionInitScript types.String                       `tfsdk:"edge_function_init_script"`
+	Environments           []CDNConfigurationEnvironmentModel `tfsdk:"environment"`
+	ConfigurationIDs       types.Map                          `tfsdk:"configuration_ids"`
+}
+
+// CDNConfigurationEnvironmentModel holds the overrides of one environment. Nil
+// or null overrides use the base value of the set.
+type CDNConfigurationEnvironmentModel struct {
+	EnvironmentID types.String    `tfsdk:"environment_id"`
+	Origins       []OriginModel   `tfsdk:"origins"`
+	Hostnames     []HostnameModel `tfsdk:"hostnames"`
+	Experiments   types.List      `tfsdk:"experiments"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RuleModel is the structured form of a single entry of the CDN rules array. The
+// top-level conditions and features form the "if" branch of the rule, a rule
+// without conditions applies its features to every request.
+type RuleModel struct {
+	Conditions []RuleConditionModel `tfsdk:"condition"`
+	Features   *RuleFeaturesModel   `tfsdk:"features"`
+	ElseIf     []RuleElseIfModel    `tfsdk:"elseif"`
+	Else       *RuleFeaturesModel   `tfsdk:"else"`
+}
+
+type RuleElseIfModel struct {
+	Conditions []RuleConditionModel `tfsdk:"condition"`
+	Features   *RuleFeaturesModel   `tfsdk:"features"`
+}
+
+type RuleConditionModel struct {
+	Variable types.String `tfsdk:"variable"`
+	Name     types.String `tfsdk:"name"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+type RuleFeaturesModel struct {
+	Caching      *RuleCachingModel  `tfsdk:"caching"`
+	Headers      *RuleHeadersModel  `tfsdk:"headers"`
+	URL          *RuleURLModel      `tfsdk:"url"`
+	Origin       *RuleOriginModel   `tfsdk:"origin"`
+	Response     *RuleResponseModel `tfsdk:"response"`
+	Access       *RuleAccessModel   `tfsdk:"access"`
+	EdgeFunction types.String       `tfsdk:"edge_function"`
+	JSON         types.String       `tfsdk:"json"`
+}
+
+type RuleCachingModel struct {
+	MaxAge               types.Map    `tfsdk:"max_age"`
+	ClientMaxAge         types.String `tfsdk:"client_max_age"`
+	ServiceWorkerMaxAge  types.String `tfsdk:"service_worker_max_age"`
+	StaleWhileRevalidate types.String `tfsdk:"stale_while_revalidate"`
+	BypassCache          types.Bool   `tfsdk:"bypass_cache"`
+	BypassClientCache    types.Bool   `tfsdk:"bypass_client_cache"`
+}
+
+type RuleHeadersModel struct {
+	SetRequestHeaders     types.Map  `tfsdk:"set_request_headers"`
+	SetResponseHeaders    types.Map  `tfsdk:"set_response_headers"`
+	AddResponseHeaders    types.Map  `tfsdk:"add_response_headers"`
+	RemoveRequestHeaders  types.List `tfsdk:"remove_request_headers"`
+	RemoveResponseHeaders types.List `tfsdk:"remove_response_headers"`
+}
+
+type RuleURLModel struct {
+	URLRewrite  []RuleURLRewriteModel `tfsdk:"url_rewrite"`
+	URLRedirect *RuleURLRedirectModel `tfsdk:"url_redirect"`
+}
+
+type RuleURLRewriteModel struct {
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	Syntax      types.String `tfsdk:"syntax"`
+}
+
+type RuleURLRedirectModel struct {
+	Code        types.Int64  `tfsdk:"code"`
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	Syntax      types.String `tfsdk:"syntax"`
+}
+
+type RuleOriginModel struct {
+	SetOrigin types.String `tfsdk:"set_origin"`
+}
+
+type RuleResponseModel struct {
+	SetStatusCode types.Int64 `tfsdk:"set_status_code"`
+}
+
+type RuleAccessModel struct {
+	DenyAccess types.Bool `tfsdk:"deny_access"`
+}
@@ -19,8 +19,15 @@ type EnvironmentModel struct {
 }
 
 type EnvironmentsModel struct {
-	Type         types.String       `tfsdk:"type"`
-	Id           types.String       `tfsdk:"id"`
-	TotalItems   types.Int32        `tfsdk:"total_items"`
-	Environments []EnvironmentModel `tfsdk:"items"`
+	PropertyID   types.String       `tfsdk:"property_id"`
+	TotalItems   types.Int32        `tfsdk:"item_count"`
+	Environments []EnvironmentModel `tfsdk:"environments"`
+}
+
+// EnvironmentResourceModel adds the attributes of edgio_environment that are not
+// returned by the API to the environment.
+type EnvironmentResourceModel struct {
+	EnvironmentModel
+	PreserveCache      types.Bool `tfsdk:"preserve_cache"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type EnvironmentPromotionModel struct {
+	SourceEnvironmentID   types.String `tfsdk:"source_environment_id"`
+	SourceConfigurationID types.String `tfsdk:"source_configuration_id"`
+	TargetEnvironmentID   types.String `tfsdk:"target_environment_id"`
+	TargetConfigurationID types.String `tfsdk:"target_configuration_id"`
+	HostnameMapping       types.Map    `tfsdk:"hostname_mapping"`
+	OriginHostnameMapping types.Map    `tfsdk:"origin_hostname_mapping"`
+	OriginNameMapping     types.Map    `tfsdk:"origin_name_mapping"`
+}
@@ -16,3 +16,11 @@ type PropertiesModel struct {
 	ItemCount      types.Int32     `tfsdk:"item_count"`
 	Properties     []PropertyModel `tfsdk:"properties"`
 }
+
+// PropertyResourceModel adds the attributes of edgio_property that are not
+// returned by the API to the property.
+type PropertyResourceModel struct {
+	PropertyModel
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"terraform-provider-edgio/internal/edgio_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderData is passed from the provider to resources and data sources
+// through ResourceData and DataSourceData.
+type ProviderData struct {
+	Client            edgio_api.EdgioClientInterface
+	OrganizationID    types.String
+	DefaultPropertyID types.String
+	Version           string
+	APIURL            string
+	Features          ProviderFeatures
+}
+
+// ProviderFeatures holds the optional provider behaviours configured in the
+// features block of the provider.
+type ProviderFeatures struct {
+	// RulesDiffWarnings reports a per-rule diff of edgio_cdn_configuration
+	// rules as a plan warning.
+	RulesDiffWarnings bool
+}
+
+// ProviderDataFrom returns the data the provider passes to the Configure method
+// of resources and data sources, or nil before the provider is configured, e.g.
+// while validating the configuration.
+func ProviderDataFrom(providerData any, diags *diag.Diagnostics) *ProviderData {
+	if providerData == nil {
+		return nil
+	}
+
+	data, ok := providerData.(*ProviderData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *models.ProviderData, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil
+	}
+
+	return data
+}
+
+// RequireClient adds an error and returns false if a resource or data source
+// is used before the provider is configured.
+func RequireClient(client edgio_api.EdgioClientInterface, diags *diag.Diagnostics) bool {
+	if client != nil {
+		return true
+	}
+
+	diags.AddError(
+		"Unconfigured Provider",
+		"The Edgio provider has not been configured yet. Make sure its configuration does not depend on "+
+			"values that are only known after apply.",
+	)
+	return false
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ProviderModel struct {
+	ClientID           types.String           `tfsdk:"client_id"`
+	ClientSecret       types.String           `tfsdk:"client_secret"`
+	AccessToken        types.String           `tfsdk:"access_token"`
+	AccessTokenFile    types.String           `tfsdk:"access_token_file"`
+	OIDCToken          types.String           `tfsdk:"oidc_token"`
+	OIDCTokenFile      types.String           `tfsdk:"oidc_token_file"`
+	HTTPProxy          types.String           `tfsdk:"http_proxy"`
+	CACertFile         types.String           `tfsdk:"ca_cert_file"`
+	ClientCertFile     types.String           `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String           `tfsdk:"client_key_file"`
+	RequestTimeout     types.String           `tfsdk:"request_timeout"`
+	InsecureSkipVerify types.Bool             `tfsdk:"insecure_skip_verify"`
+	OrganizationID     types.String           `tfsdk:"organization_id"`
+	DefaultPropertyID  types.String           `tfsdk:"default_property_id"`
+	APIURL             types.String           `tfsdk:"api_url"`
+	TokenURL           types.String           `tfsdk:"token_url"`
+	Features           *ProviderFeaturesModel `tfsdk:"features"`
+}
+
+type ProviderFeaturesModel struct {
+	RulesDiffWarnings types.Bool `tfsdk:"rules_diff_warnings"`
+}
+
+type ProviderInfoModel struct {
+	Version types.String `tfsdk:"version"`
+	APIURL  types.String `tfsdk:"api_url"`
+	Scopes  types.List   `tfsdk:"scopes"`
+}
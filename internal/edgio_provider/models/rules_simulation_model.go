@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RulesSimulationModel struct {
+	Rules            types.String           `tfsdk:"rules"`
+	Request          *SimulatedRequestModel `tfsdk:"request"`
+	MatchedRules     types.List             `tfsdk:"matched_rules"`
+	Features         types.String           `tfsdk:"features"`
+	Origin           types.String           `tfsdk:"origin"`
+	MaxAge           types.Map              `tfsdk:"max_age"`
+	BypassCache      types.Bool             `tfsdk:"bypass_cache"`
+	RequestHeaders   types.Map              `tfsdk:"request_headers"`
+	ResponseHeaders  types.Map              `tfsdk:"response_headers"`
+	StatusCode       types.Int64            `tfsdk:"status_code"`
+	RewrittenPath    types.String           `tfsdk:"rewritten_path"`
+	RedirectCode     types.Int64            `tfsdk:"redirect_code"`
+	RedirectLocation types.String           `tfsdk:"redirect_location"`
+	EdgeFunction     types.String           `tfsdk:"edge_function"`
+}
+
+type SimulatedRequestModel struct {
+	Method  types.String `tfsdk:"method"`
+	Scheme  types.String `tfsdk:"scheme"`
+	Host    types.String `tfsdk:"host"`
+	Path    types.String `tfsdk:"path"`
+	Query   types.String `tfsdk:"query"`
+	Headers types.Map    `tfsdk:"headers"`
+	Cookies types.Map    `tfsdk:"cookies"`
+	Country types.String `tfsdk:"country"`
+}
@@ -24,3 +24,11 @@ type TLSCertsModel struct {
 	ItemCount     types.Int32    `tfsdk:"item_count"`
 	Certificates  []TLSCertModel `tfsdk:"certificates"`
 }
+
+// TLSCertResourceModel extends TLSCertModel with the attributes that only exist
+// on the edgio_tls_cert resource.
+type TLSCertResourceModel struct {
+	TLSCertModel
+	PrivateKeyWO     types.String `tfsdk:"private_key_wo"`
+	PrivateKeySHA256 types.String `tfsdk:"private_key_sha256"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type TLSCertRequestModel struct {
+	ID               types.String `tfsdk:"id"`
+	EnvironmentID    types.String `tfsdk:"environment_id"`
+	KeyAlgorithm     types.String `tfsdk:"key_algorithm"`
+	RSABits          types.Int64  `tfsdk:"rsa_bits"`
+	ECDSACurve       types.String `tfsdk:"ecdsa_curve"`
+	CommonName       types.String `tfsdk:"common_name"`
+	Organization     types.String `tfsdk:"organization"`
+	DNSNames         types.List   `tfsdk:"dns_names"`
+	RotationVersion  types.Int64  `tfsdk:"rotation_version"`
+	PrivateKeyPEM    types.String `tfsdk:"private_key_pem"`
+	CertRequestPEM   types.String `tfsdk:"cert_request_pem"`
+	SignedCert       types.String `tfsdk:"signed_cert"`
+	IntermediateCert types.String `tfsdk:"intermediate_cert"`
+	TLSCertID        types.String `tfsdk:"tls_cert_id"`
+	Status           types.String `tfsdk:"status"`
+	Expiration       types.String `tfsdk:"expiration"`
+}
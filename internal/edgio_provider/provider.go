@@ -2,8 +2,14 @@ package edgio_provider
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,20 +17,33 @@ import (
 
 	"terraform-provider-edgio/internal/edgio_api"
 	"terraform-provider-edgio/internal/edgio_provider/data_sources"
+	"terraform-provider-edgio/internal/edgio_provider/models"
 	"terraform-provider-edgio/internal/edgio_provider/resources"
 )
 
 // Provider implements the provider.Provider interface.
 type Provider struct {
+	// client is only set by NewMockedProvider, otherwise Configure creates it.
 	client edgio_api.EdgioClientInterface
+
+	// version is set to the provider version when the binary is built and
+	// released, "dev" for local builds and "test" for acceptance tests.
+	version string
 }
 
-func New() provider.Provider {
-	return &Provider{}
+const (
+	defaultTokenURL = "https://id.edgio.app/connect/token"
+	defaultAPIURL   = "https://edgioapis.com"
+)
+
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &Provider{version: version}
+	}
 }
 
 func NewMockedProvider(client edgio_api.EdgioClientInterface) provider.Provider {
-	return &Provider{client: client}
+	return &Provider{client: client, version: "test"}
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -36,62 +55,189 @@ var (
 func (p *Provider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
 	// Set the provider metadata (optional)
 	response.TypeName = "edgio"
-	response.Version = "1.0.0"
+	response.Version = p.version
 }
 
 // Configure configures the provider with user-provided configuration.
 func (p *Provider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
-	var config struct {
-		ClientID     types.String `tfsdk:"client_id"`
-		ClientSecret types.String `tfsdk:"client_secret"`
-	}
+	var config models.ProviderModel
 
 	if err := request.Config.Get(ctx, &config); err != nil {
 		response.Diagnostics.AddError("Failed to read provider configuration", "error")
 		return
 	}
 
+	credentials := providerCredentials(config, &response.Diagnostics)
+	transport := providerTransport(config, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	transport.UserAgent = fmt.Sprintf("terraform-provider-edgio/%s terraform/%s", p.version, request.TerraformVersion)
+
+	apiURL := defaultAPIURL
+	if !config.APIURL.IsNull() {
+		apiURL = strings.TrimSuffix(config.APIURL.ValueString(), "/")
+	}
+	tokenURL := defaultTokenURL
+	if !config.TokenURL.IsNull() {
+		tokenURL = config.TokenURL.ValueString()
+	}
+
 	// For mock we don't need to create a new client, as mock
 	// will handle all the calls
 	if p.client == nil {
-		client := edgio_api.NewEdgioClient(
-			config.ClientID.ValueString(),
-			config.ClientSecret.ValueString(),
-			"https://id.edgio.app/connect/token",
-			"https://edgioapis.com",
+		client, err := edgio_api.NewEdgioClient(
+			credentials,
+			transport,
+			tokenURL,
+			apiURL,
 		)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Unable to Create Edgio API Client",
+				fmt.Sprintf("Could not configure the HTTP transport: %s", err),
+			)
+			return
+		}
 		p.client = client
 	}
+
+	providerData := &models.ProviderData{
+		Client:            p.client,
+		OrganizationID:    config.OrganizationID,
+		DefaultPropertyID: config.DefaultPropertyID,
+		Version:           p.version,
+		APIURL:            apiURL,
+		Features:          providerFeatures(config.Features),
+	}
+	response.ResourceData = providerData
+	response.DataSourceData = providerData
+}
+
+// providerFeatures returns the configured feature flags, with the defaults of
+// the flags that are not set.
+func providerFeatures(config *models.ProviderFeaturesModel) models.ProviderFeatures {
+	features := models.ProviderFeatures{
+		RulesDiffWarnings: true,
+	}
+
+	if config != nil && !config.RulesDiffWarnings.IsNull() {
+		features.RulesDiffWarnings = config.RulesDiffWarnings.ValueBool()
+	}
+
+	return features
+}
+
+// providerCredentials validates that exactly one authentication mode is
+// configured and returns its credentials.
+func providerCredentials(config models.ProviderModel, diags *diag.Diagnostics) edgio_api.Credentials {
+	modes := map[string]types.String{
+		"client_secret":     config.ClientSecret,
+		"access_token":      config.AccessToken,
+		"access_token_file": config.AccessTokenFile,
+		"oidc_token":        config.OIDCToken,
+		"oidc_token_file":   config.OIDCTokenFile,
+	}
+
+	var configured []string
+	for name, value := range modes {
+		if !value.IsNull() {
+			configured = append(configured, name)
+		}
+	}
+	sort.Strings(configured)
+
+	if len(configured) != 1 {
+		diags.AddError(
+			"Invalid Authentication Configuration",
+			fmt.Sprintf("Exactly one of client_secret, access_token, access_token_file, oidc_token and oidc_token_file "+
+				"must be set, got: [%s].", strings.Join(configured, ", ")),
+		)
+		return edgio_api.Credentials{}
+	}
+
+	switch configured[0] {
+	case "client_secret", "oidc_token", "oidc_token_file":
+		if config.ClientID.IsNull() {
+			diags.AddAttributeError(
+				path.Root("client_id"),
+				"Missing Client ID",
+				fmt.Sprintf("'client_id' is required when authenticating with '%s'.", configured[0]),
+			)
+		}
+	}
+
+	return edgio_api.Credentials{
+		ClientID:        config.ClientID.ValueString(),
+		ClientSecret:    config.ClientSecret.ValueString(),
+		AccessToken:     config.AccessToken.ValueString(),
+		AccessTokenFile: config.AccessTokenFile.ValueString(),
+		OIDCToken:       config.OIDCToken.ValueString(),
+		OIDCTokenFile:   config.OIDCTokenFile.ValueString(),
+	}
+}
+
+// providerTransport returns the HTTP transport options of the provider.
+func providerTransport(config models.ProviderModel, diags *diag.Diagnostics) edgio_api.TransportOptions {
+	transport := edgio_api.TransportOptions{
+		ProxyURL:           config.HTTPProxy.ValueString(),
+		CACertFile:         config.CACertFile.ValueString(),
+		ClientCertFile:     config.ClientCertFile.ValueString(),
+		ClientKeyFile:      config.ClientKeyFile.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The certificates of the Edgio API and token endpoint are not verified, which exposes credentials and "+
+				"configuration to man-in-the-middle attacks. Only use 'insecure_skip_verify' with local stand-ins of the API.",
+		)
+	}
+
+	if config.ClientCertFile.IsNull() != config.ClientKeyFile.IsNull() {
+		diags.AddError(
+			"Invalid Client Certificate Configuration",
+			"'client_cert_file' and 'client_key_file' must be set together.",
+		)
+	}
+
+	if !config.RequestTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("'request_timeout' must be a positive duration such as \"30s\" or \"2m\", got: %q.", config.RequestTimeout.ValueString()),
+			)
+		}
+		transport.Timeout = timeout
+	}
+
+	return transport
 }
 
 func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		func() datasource.DataSource {
-			return data_sources.NewPropertiesDataSource(p.client)
-		},
-		func() datasource.DataSource {
-			return data_sources.NewEnvironmentsDataSource(p.client)
-		},
-		func() datasource.DataSource {
-			return data_sources.NewTlsCertsDataSource(p.client)
-		},
+		data_sources.NewPropertiesDataSource,
+		data_sources.NewEnvironmentsDataSource,
+		data_sources.NewTlsCertsDataSource,
+		data_sources.NewRulesSimulationDataSource,
+		data_sources.NewProviderInfoDataSource,
 	}
 }
 
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		func() resource.Resource {
-			return resources.NewPropertyResource(p.client)
-		},
-		func() resource.Resource {
-			return resources.NewEnvironmentResource(p.client)
-		},
-		func() resource.Resource {
-			return resources.NewTLSCertsResource(p.client)
-		},
-		func() resource.Resource {
-			return resources.NewCDNConfigurationResource(p.client)
-		},
+		resources.NewPropertyResource,
+		resources.NewEnvironmentResource,
+		resources.NewTLSCertsResource,
+		resources.NewTLSCertRequestResource,
+		resources.NewCDNConfigurationResource,
+		resources.NewCDNConfigurationSetResource,
+		resources.NewEnvironmentPromotionResource,
+		resources.NewEnvironmentVariableResource,
 	}
 }
 
@@ -99,12 +245,87 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "Client ID for OAuth2 authentication.",
-				Required:            true,
+				MarkdownDescription: "Client ID for OAuth2 authentication. Required with `client_secret`, `oidc_token` and `oidc_token_file`.",
+				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
 				MarkdownDescription: "Client Secret for OAuth2 authentication.",
-				Required:            true,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Pre-issued access token used instead of requesting tokens. It must carry the `app.accounts` and `app.config` scopes.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"access_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing a pre-issued access token. " +
+					"The file is read again a minute before the token expires, so an external process can rotate it.",
+				Optional: true,
+			},
+			"oidc_token": schema.StringAttribute{
+				MarkdownDescription: "JWT issued by an OIDC identity provider, e.g. the workload identity of a CI job, " +
+					"exchanged for access tokens with the JWT bearer grant (RFC 7523).",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"oidc_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the OIDC JWT. The file is read again for every token exchange.",
+				Optional:            true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy for API and token requests, e.g. `http://proxy.example.com:3128`. " +
+					"Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM file with CA certificates trusted in addition to the system CA certificates, " +
+					"e.g. the CA of a TLS-intercepting proxy.",
+				Optional: true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM client certificate for mutual TLS. Requires `client_key_file`.",
+				Optional:            true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM private key of `client_cert_file`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single API or token request as a duration, e.g. `2m`. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the verification of TLS certificates. Only meant for local stand-ins of the API.",
+				Optional:            true,
+			},
+			"api_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Edgio API. Defaults to `https://edgioapis.com`. " +
+					"Only meant for stand-ins of the API such as a local fake.",
+				Optional: true,
+			},
+			"token_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the OAuth2 token endpoint. Defaults to `https://id.edgio.app/connect/token`.",
+				Optional:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Default organization of `edgio_property` resources and the `edgio_properties` data source.",
+				Optional:            true,
+			},
+			"default_property_id": schema.StringAttribute{
+				MarkdownDescription: "Default property of `edgio_environment` resources and the `edgio_environments` data source.",
+				Optional:            true,
+			},
+			"features": schema.SingleNestedAttribute{
+				MarkdownDescription: "Optional provider behaviours.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"rules_diff_warnings": schema.BoolAttribute{
+						MarkdownDescription: "Whether plans of `edgio_cdn_configuration` report a per-rule diff as a warning " +
+							"when the rules change. Defaults to `true`.",
+						Optional: true,
+					},
+				},
 			},
 		},
 	}
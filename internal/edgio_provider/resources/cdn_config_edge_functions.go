@@ -0,0 +1,149 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// planEdgeFunctions reads the edge function files, plans the content hash and
+// checks that every edge function referenced by the rules has a source. Sources
+// read from files are planned as unknown when their hash changes, so that the
+// plan shows the hash instead of whole JavaScript bundles.
+func planEdgeFunctions(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, rulesPath path.Path) {
+	var directory, initScriptFile, priorHash types.String
+	var sources types.Map
+	var initScript types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("edge_functions_directory"), &directory)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("edge_function_init_script_file"), &initScriptFile)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("edge_functions_sources"), &sources)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("edge_function_init_script"), &initScript)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("edge_functions_hash"), &priorHash)...)
+	}
+	if resp.Diagnostics.HasError() || directory.IsUnknown() || initScriptFile.IsUnknown() {
+		return
+	}
+
+	plannedSources, plannedInitScript, known := readEdgeFunctionFiles(directory, initScriptFile, sources, initScript, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("edge_functions_hash"), types.StringUnknown())...)
+	} else {
+		hash := utility.EdgeFunctionsHash(plannedSources, plannedInitScript)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("edge_functions_hash"), types.StringValue(hash))...)
+
+		if hash != priorHash.ValueString() {
+			if !directory.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("edge_functions_sources"), types.MapUnknown(types.StringType))...)
+			}
+			if !initScriptFile.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("edge_function_init_script"), types.StringUnknown())...)
+			}
+		}
+	}
+
+	// Only configured sources count, sources that merely remain in state from an
+	// earlier configuration are not uploaded again.
+	var configuredSources types.Map
+	var rules customtypes.RulesJSON
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("edge_functions_sources"), &configuredSources)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	if resp.Diagnostics.HasError() || configuredSources.IsUnknown() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	available := utility.MapValueToStringMap(configuredSources)
+	if !directory.IsNull() {
+		available = plannedSources
+	}
+
+	// Malformed rules are reported by ValidateConfig.
+	references, _ := utility.RuleEdgeFunctionReferences(rules.ValueString())
+	for _, reference := range references {
+		if !utility.HasEdgeFunctionSource(available, reference.EdgeFunction) {
+			resp.Diagnostics.AddAttributeError(
+				rulesPath,
+				"Missing Edge Function Source",
+				fmt.Sprintf("The rule at %s hands requests to edge function '%s', which has no source in edge_functions_sources or edge_functions_directory.",
+					reference.Pointer, reference.EdgeFunction),
+			)
+		}
+	}
+}
+
+// readEdgeFunctionFiles returns the edge function sources and init script,
+// from the configured files or otherwise from the planned values, and false if
+// the planned values are not known yet.
+func readEdgeFunctionFiles(directory, initScriptFile types.String, sources types.Map, initScript types.String, diags *diag.Diagnostics) (map[string]string, string, bool) {
+	known := true
+
+	var plannedSources map[string]string
+	switch {
+	case !directory.IsNull():
+		var err error
+		plannedSources, err = utility.ReadEdgeFunctionsDirectory(directory.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("edge_functions_directory"), "Invalid Edge Functions Directory", err.Error())
+		}
+	case sources.IsUnknown():
+		known = false
+	default:
+		plannedSources = utility.MapValueToStringMap(sources)
+	}
+
+	var plannedInitScript string
+	switch {
+	case !initScriptFile.IsNull():
+		var err error
+		plannedInitScript, err = utility.ReadEdgeFunctionInitScript(initScriptFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("edge_function_init_script_file"), "Invalid Edge Function Init Script File", err.Error())
+		}
+	case initScript.IsUnknown():
+		known = false
+	default:
+		plannedInitScript = initScript.ValueString()
+	}
+
+	return plannedSources, plannedInitScript, known
+}
+
+// loadEdgeFunctionFiles fills in the sources read from files for the upload and
+// makes sure that the files did not change since the plan.
+func loadEdgeFunctionFiles(plan *models.CDNConfigurationModel, diags *diag.Diagnostics) {
+	if plan.EdgeFunctionsDirectory.IsNull() && plan.EdgeFunctionInitScriptFile.IsNull() {
+		return
+	}
+
+	sources, initScript, _ := readEdgeFunctionFiles(plan.EdgeFunctionsDirectory, plan.EdgeFunctionInitScriptFile,
+		plan.EdgeFunctionsSources, plan.EdgeFunctionInitScript, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if hash := utility.EdgeFunctionsHash(sources, initScript); !plan.EdgeFunctionsHash.IsUnknown() && hash != plan.EdgeFunctionsHash.ValueString() {
+		diags.AddError(
+			"Edge Function Sources Changed",
+			"The edge function files changed after the plan was created. Run the plan again to upload the current files.",
+		)
+		return
+	}
+
+	if !plan.EdgeFunctionsDirectory.IsNull() {
+		plan.EdgeFunctionsSources = utility.StringMapToMapValue(&sources)
+	}
+	if !plan.EdgeFunctionInitScriptFile.IsNull() {
+		plan.EdgeFunctionInitScript = types.StringValue(initScript)
+	}
+}
@@ -2,35 +2,72 @@ package resources
 
 import (
 	"context"
+	"maps"
 	"terraform-provider-edgio/internal/edgio_provider/utility"
 
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// JSONEqualityModifier is a custom plan modifier that formats JSON strings always the same.
-type JSONEqualityModifier struct{}
+// addRulesDiffWarning reports the rules that differ between prior and planned
+// as a warning.
+func addRulesDiffWarning(diags *diag.Diagnostics, attributePath path.Path, prior, planned types.String) {
+	if prior.IsNull() || prior.IsUnknown() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
 
-func (m JSONEqualityModifier) Description(ctx context.Context) string {
-	return "Formats JSON strings always the same, ignoring formatting differences"
-}
+	if prior.ValueString() == planned.ValueString() {
+		return
+	}
+
+	diff, err := utility.DiffRulesJSON(prior.ValueString(), planned.ValueString())
+	if err != nil || diff == "" {
+		return
+	}
 
-func (m JSONEqualityModifier) MarkdownDescription(ctx context.Context) string {
-	return "Formats JSON strings always the same, ignoring formatting differences"
+	diags.AddAttributeWarning(
+		attributePath,
+		"CDN Rules Changed",
+		"The following rules change with this plan:\n\n"+diff,
+	)
 }
 
-func (m JSONEqualityModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// If the plan is unknown, we can't make any decisions.
-	if req.PlanValue.IsUnknown() {
+// planKeyAttributes sets the key attribute of the elements of a keyed
+// collection to their map key, so that it is known at plan time.
+func planKeyAttributes(ctx context.Context, plan *tfsdk.Plan, diags *diag.Diagnostics, mapName, key string) {
+	var planMap types.Map
+	diags.Append(plan.GetAttribute(ctx, path.Root(mapName), &planMap)...)
+	if diags.HasError() || planMap.IsNull() || planMap.IsUnknown() {
 		return
 	}
 
-	json, err := utility.MinifyJSON(req.PlanValue.ValueString())
+	keyed := map[string]attr.Value{}
+	for name, element := range planMap.Elements() {
+		element, elementDiags := withObjectAttribute(element, key, types.StringValue(name))
+		diags.Append(elementDiags...)
+		keyed[name] = element
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error parsing plan JSON", err.Error())
+	keyedValue, mapDiags := types.MapValue(planMap.ElementType(ctx), keyed)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
 		return
 	}
 
-	resp.PlanValue = types.StringValue(json)
+	diags.Append(plan.SetAttribute(ctx, path.Root(mapName), keyedValue)...)
+}
+
+func withObjectAttribute(value attr.Value, name string, attributeValue attr.Value) (attr.Value, diag.Diagnostics) {
+	object, ok := value.(types.Object)
+	if !ok || object.IsNull() || object.IsUnknown() {
+		return value, nil
+	}
+
+	attributes := maps.Clone(object.Attributes())
+	attributes[name] = attributeValue
+
+	return types.ObjectValue(object.AttributeTypes(context.Background()), attributes)
 }
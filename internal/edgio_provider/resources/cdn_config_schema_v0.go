@@ -0,0 +1,287 @@
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cdnConfigurationSchemaV0 is the schema of edgio_cdn_configuration as released
+// before the schema was versioned. It is only used to read prior states and must
+// not change with the current schema.
+func cdnConfigurationSchemaV0() schema.Schema {
+	return schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required: true,
+			},
+			"configuration_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"rules": schema.StringAttribute{
+				Required: true,
+			},
+			"origins": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"type": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"hosts": schema.ListNestedAttribute{
+							Optional: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"weight": schema.Int64Attribute{
+										Optional: true,
+										Computed: true,
+									},
+									"dns_max_ttl": schema.Int64Attribute{
+										Optional: true,
+										Computed: true,
+									},
+									"dns_preference": schema.StringAttribute{
+										Optional: true,
+										Computed: true,
+									},
+									"max_hard_pool": schema.Int64Attribute{
+										Optional: true,
+										Computed: true,
+									},
+									"dns_min_ttl": schema.Int64Attribute{
+										Optional: true,
+										Computed: true,
+									},
+									"location": schema.ListNestedAttribute{
+										Optional: true,
+										Computed: true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"port": schema.Int64Attribute{
+													Optional: true,
+													Computed: true,
+												},
+												"hostname": schema.StringAttribute{
+													Optional: true,
+													Computed: true,
+												},
+											},
+										},
+									},
+									"max_pool": schema.Int64Attribute{
+										Optional: true,
+										Computed: true,
+									},
+									"balancer": schema.StringAttribute{
+										Optional: true,
+										Computed: true,
+									},
+									"scheme": schema.StringAttribute{
+										Optional: true,
+										Computed: true,
+									},
+									"override_host_header": schema.StringAttribute{
+										Optional: true,
+										Computed: true,
+									},
+									"sni_hint_and_strict_san_check": schema.StringAttribute{
+										Optional: true,
+										Computed: true,
+									},
+									"use_sni": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"balancer": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"override_host_header": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"shields": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"apac": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"emea": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"us_west": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"us_east": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+							},
+						},
+						"pci_certified_shields": schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"tls_verify": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"use_sni": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"sni_hint_and_strict_san_check": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"allow_self_signed_certs": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"pinned_certs": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Computed:    true,
+								},
+							},
+						},
+						"retry": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Computed:    true,
+								},
+								"ignore_retry_after_header": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"after_seconds": schema.Int64Attribute{
+									Optional: true,
+									Computed: true,
+								},
+								"max_requests": schema.Int64Attribute{
+									Optional: true,
+									Computed: true,
+								},
+								"max_wait_seconds": schema.Int64Attribute{
+									Optional: true,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"hostnames": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hostname": schema.StringAttribute{
+							Required: true,
+						},
+						"default_origin_name": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"report_code": schema.Int64Attribute{
+							Optional: true,
+							Computed: true,
+						},
+						"tls": schema.SingleNestedAttribute{
+							Optional: true,
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"npn": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"alpn": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"protocols": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"use_sigalgs": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"sni": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"sni_strict": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"sni_host_match": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"client_renegotiation": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"options": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"cipher_list": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"named_curve": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"oscp": schema.BoolAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"pem": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+								"ca": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+								},
+							},
+						},
+						"directory": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"experiments": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"edge_functions_sources": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"edge_function_init_script": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
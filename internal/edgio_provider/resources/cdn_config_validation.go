@@ -0,0 +1,384 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateCDNConfigReferences checks that origin names and hostnames are unique
+// and that every origin referenced by a hostname or rule is defined. Values that
+// are not known yet are skipped, they are checked again during apply.
+func validateCDNConfigReferences(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var rule types.List
+	var rules customtypes.RulesJSON
+	diags.Append(config.GetAttribute(ctx, path.Root("rule"), &rule)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	origins, originsKnown := namedElements(ctx, config, diags, "origins", "origins_by_name", "name")
+	hostnames, _ := namedElements(ctx, config, diags, "hostnames", "hostnames_by_name", "hostname")
+	if diags.HasError() {
+		return
+	}
+
+	validateNamedElements(diags, origins, originsKnown, hostnames)
+
+	originNames := elementNames(origins, originsKnown)
+	validateRuleBlockOrigins(diags, originNames, rule)
+	validateRulesOrigins(diags, originNames, rules)
+}
+
+// validateNamedElements runs the checks of validateCDNConfigReferences on the
+// origins and hostnames of one configuration.
+func validateNamedElements(diags *diag.Diagnostics, origins []namedElement, originsKnown bool, hostnames []namedElement) {
+	originNames := map[string]bool{}
+
+	for _, origin := range origins {
+		if origin.name.IsUnknown() {
+			originsKnown = false
+			continue
+		}
+
+		if originNames[origin.name.ValueString()] {
+			diags.AddAttributeError(
+				origin.namePath,
+				"Duplicate Origin Name",
+				fmt.Sprintf("Origin name '%s' is used by more than one origin.", origin.name.ValueString()),
+			)
+		}
+		originNames[origin.name.ValueString()] = true
+	}
+
+	checkOrigin := func(originPath path.Path, value attr.Value) {
+		origin, ok := value.(types.String)
+		if !ok || !originsKnown || origin.IsNull() || origin.IsUnknown() {
+			return
+		}
+
+		if !originNames[origin.ValueString()] && !utility.IsBuiltInOrigin(origin.ValueString()) {
+			diags.AddAttributeError(
+				originPath,
+				"Unknown Origin",
+				fmt.Sprintf("Origin '%s' is not defined in origins.", origin.ValueString()),
+			)
+		}
+	}
+
+	seenHostnames := map[string]bool{}
+	for _, hostname := range hostnames {
+		if name := hostname.name; !name.IsNull() && !name.IsUnknown() {
+			if err := utility.ValidateHostname(name.ValueString()); err != nil {
+				diags.AddAttributeError(hostname.namePath, "Invalid Hostname", err.Error())
+			}
+
+			key := strings.ToLower(name.ValueString())
+			if seenHostnames[key] {
+				diags.AddAttributeError(
+					hostname.namePath,
+					"Duplicate Hostname",
+					fmt.Sprintf("Hostname '%s' is defined more than once.", name.ValueString()),
+				)
+			}
+			seenHostnames[key] = true
+		}
+
+		checkOrigin(hostname.path.AtName("default_origin_name"), objectAttribute(hostname.value, "default_origin_name"))
+	}
+}
+
+// validateRuleBlockOrigins checks that every origin set by the structured rule
+// blocks is defined. origins are the names of the defined origins, nil if they
+// are not known yet.
+func validateRuleBlockOrigins(diags *diag.Diagnostics, origins []string, rule types.List) {
+	if origins == nil {
+		return
+	}
+
+	for i, element := range rule.Elements() {
+		rulePath := path.Root("rule").AtListIndex(i)
+
+		checkRuleOrigin(diags, origins, rulePath.AtName("features").AtName("origin").AtName("set_origin"),
+			objectAttribute(element, "features", "origin", "set_origin"))
+		checkRuleOrigin(diags, origins, rulePath.AtName("else").AtName("origin").AtName("set_origin"),
+			objectAttribute(element, "else", "origin", "set_origin"))
+
+		if elseIfs, ok := objectAttribute(element, "elseif").(types.List); ok {
+			for j, elseIf := range elseIfs.Elements() {
+				checkRuleOrigin(diags, origins, rulePath.AtName("elseif").AtListIndex(j).AtName("features").AtName("origin").AtName("set_origin"),
+					objectAttribute(elseIf, "features", "origin", "set_origin"))
+			}
+		}
+	}
+}
+
+func checkRuleOrigin(diags *diag.Diagnostics, origins []string, originPath path.Path, value attr.Value) {
+	origin, ok := value.(types.String)
+	if !ok || origin.IsNull() || origin.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(origins, origin.ValueString()) && !utility.IsBuiltInOrigin(origin.ValueString()) {
+		diags.AddAttributeError(
+			originPath,
+			"Unknown Origin",
+			fmt.Sprintf("Origin '%s' is not defined in origins.", origin.ValueString()),
+		)
+	}
+}
+
+// validateRulesOrigins checks that every origin set by the rules JSON is
+// defined, quoting the JSON pointer of the set_origin. origins are the names of
+// the defined origins, nil if they are not known yet.
+func validateRulesOrigins(diags *diag.Diagnostics, origins []string, rules customtypes.RulesJSON) {
+	if origins == nil || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	// Malformed rules are reported by validateRulesSchema.
+	references, err := utility.RuleOriginReferences(rules.ValueString())
+	if err != nil {
+		return
+	}
+
+	for _, reference := range references {
+		if !slices.Contains(origins, reference.Origin) && !utility.IsBuiltInOrigin(reference.Origin) {
+			diags.AddAttributeError(
+				path.Root("rules"),
+				"Unknown Origin",
+				fmt.Sprintf("%s: origin '%s' is not defined in origins.", reference.Pointer, reference.Origin),
+			)
+		}
+	}
+}
+
+// objectAttribute walks nested object attributes by name, returning nil if any
+// object on the way is null, unknown or lacks the attribute.
+func objectAttribute(value attr.Value, names ...string) attr.Value {
+	for _, name := range names {
+		object, ok := value.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			return nil
+		}
+
+		value, ok = object.Attributes()[name]
+		if !ok {
+			return nil
+		}
+	}
+
+	return value
+}
+
+// namedElement is an origin or hostname from either the list or the keyed form.
+type namedElement struct {
+	path     path.Path
+	namePath path.Path
+	name     types.String
+	value    attr.Value
+}
+
+// attributeGetter is implemented by tfsdk.Config, tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+// namedElements returns the elements of whichever of the list and the keyed form
+// is configured, and false if the collection is not known yet.
+func namedElements(ctx context.Context, config attributeGetter, diags *diag.Diagnostics, listName, mapName, key string) ([]namedElement, bool) {
+	var list types.List
+	var keyed types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root(listName), &list)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(mapName), &keyed)...)
+	if list.IsUnknown() || keyed.IsUnknown() {
+		return nil, false
+	}
+
+	elements := listElements(list, path.Root(listName), key)
+
+	keys := slices.Sorted(maps.Keys(keyed.Elements()))
+	for _, name := range keys {
+		elementPath := path.Root(mapName).AtMapKey(name)
+		elements = append(elements, namedElement{path: elementPath, namePath: elementPath, name: types.StringValue(name), value: keyed.Elements()[name]})
+	}
+
+	return elements, true
+}
+
+// listElements returns the elements of a list of origins or hostnames.
+func listElements(list types.List, listPath path.Path, key string) []namedElement {
+	var elements []namedElement
+
+	for i, value := range list.Elements() {
+		elementPath := listPath.AtListIndex(i)
+		name, _ := objectAttribute(value, key).(types.String)
+		elements = append(elements, namedElement{path: elementPath, namePath: elementPath.AtName(key), name: name, value: value})
+	}
+
+	return elements
+}
+
+// declaredOriginNames returns the names of the configured origins, or nil if they are
+// not known yet.
+func declaredOriginNames(ctx context.Context, config attributeGetter, diags *diag.Diagnostics) []string {
+	origins, known := namedElements(ctx, config, diags, "origins", "origins_by_name", "name")
+	return elementNames(origins, known)
+}
+
+// elementNames returns the names of the elements, or nil if any of them is not
+// known yet.
+func elementNames(elements []namedElement, known bool) []string {
+	if !known {
+		return nil
+	}
+
+	names := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if element.name.IsUnknown() {
+			return nil
+		}
+		names = append(names, element.name.ValueString())
+	}
+
+	return names
+}
+
+// validateRulesSchema validates the rules JSON against the rules schema embedded
+// in the provider. The schema is maintained with the provider and may lag behind
+// the features the Edgio API accepts, so its violations are reported like lint
+// findings, depending on rules_lint. Rules that are not valid JSON are always
+// an error.
+func validateRulesSchema(diags *diag.Diagnostics, rulesPath path.Path, lint types.String, rules customtypes.RulesJSON) {
+	if rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	violations, err := utility.ValidateRulesJSON(rules.ValueString())
+	if err != nil {
+		diags.AddAttributeError(rulesPath, "Invalid CDN Rules", err.Error())
+		return
+	}
+
+	if lint.IsUnknown() || lint.ValueString() == utility.RulesLintOff {
+		return
+	}
+
+	for _, violation := range violations {
+		detail := fmt.Sprintf("The rules do not match the Edgio rules schema at %s", violation.Error())
+		if lint.ValueString() == utility.RulesLintError {
+			diags.AddAttributeError(rulesElementPath(rulesPath, violation.Pointer), "Invalid CDN Rules", detail)
+		} else {
+			diags.AddAttributeWarning(rulesElementPath(rulesPath, violation.Pointer), "Invalid CDN Rules", detail)
+		}
+	}
+}
+
+// rulesElementPath returns the path of the rule block the JSON pointer of the
+// compiled rules points into, if rulesPath is the rule blocks, and rulesPath
+// otherwise.
+func rulesElementPath(rulesPath path.Path, pointer string) path.Path {
+	if !rulesPath.Equal(path.Root("rule")) {
+		return rulesPath
+	}
+
+	index, _, _ := strings.Cut(strings.TrimPrefix(pointer, "/"), "/")
+	if i, err := strconv.Atoi(index); err == nil {
+		return rulesPath.AtListIndex(i)
+	}
+
+	return rulesPath
+}
+
+// validateRulesLintMode checks the value of the rules_lint attribute.
+func validateRulesLintMode(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var lint types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("rules_lint"), &lint)...)
+
+	switch lint.ValueString() {
+	case "", utility.RulesLintOff, utility.RulesLintWarn, utility.RulesLintError:
+	default:
+		diags.AddAttributeError(
+			path.Root("rules_lint"),
+			"Invalid Rules Lint Mode",
+			fmt.Sprintf("Expected one of '%s', '%s' or '%s', got '%s'.",
+				utility.RulesLintOff, utility.RulesLintWarn, utility.RulesLintError, lint.ValueString()),
+		)
+	}
+}
+
+// addRulesLintFindings reports the rules linter findings as warnings or errors,
+// depending on rules_lint. origins are the names of the defined origins, nil
+// if they are not known yet.
+func addRulesLintFindings(diags *diag.Diagnostics, rulesPath path.Path, lint types.String, rules customtypes.RulesJSON, origins []string) {
+	if rules.IsNull() || rules.IsUnknown() || lint.IsUnknown() || lint.ValueString() == utility.RulesLintOff {
+		return
+	}
+
+	findings, err := utility.LintRules(rules.ValueString(), origins)
+	if err != nil {
+		// Malformed rules are reported by validateRulesSchema.
+		return
+	}
+
+	for _, finding := range findings {
+		if lint.ValueString() == utility.RulesLintError {
+			diags.AddAttributeError(rulesElementPath(rulesPath, finding.Pointer), "CDN Rules Lint", finding.Error())
+		} else {
+			diags.AddAttributeWarning(rulesElementPath(rulesPath, finding.Pointer), "CDN Rules Lint", finding.Error())
+		}
+	}
+}
+
+// validateExactlyOneOf checks that exactly one of two alternative attributes is
+// configured.
+func validateExactlyOneOf(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, first, second string) {
+	var firstValue, secondValue attr.Value
+	diags.Append(config.GetAttribute(ctx, path.Root(first), &firstValue)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(second), &secondValue)...)
+	if diags.HasError() {
+		return
+	}
+
+	switch {
+	case !firstValue.IsNull() && !secondValue.IsNull():
+		diags.AddAttributeError(
+			path.Root(second),
+			"Conflicting Attributes",
+			fmt.Sprintf("Only one of '%s' and '%s' can be set.", first, second),
+		)
+	case firstValue.IsNull() && secondValue.IsNull():
+		diags.AddAttributeError(
+			path.Root(first),
+			"Missing Attribute",
+			fmt.Sprintf("One of '%s' or '%s' must be set.", first, second),
+		)
+	}
+}
+
+// validateAtMostOneOf checks that two alternative attributes are not configured
+// together.
+func validateAtMostOneOf(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, first, second string) {
+	var firstValue, secondValue attr.Value
+	diags.Append(config.GetAttribute(ctx, path.Root(first), &firstValue)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(second), &secondValue)...)
+	if diags.HasError() {
+		return
+	}
+
+	if !firstValue.IsNull() && !secondValue.IsNull() {
+		diags.AddAttributeError(
+			path.Root(second),
+			"Conflicting Attributes",
+			fmt.Sprintf("Only one of '%s' and '%s' can be set.", first, second),
+		)
+	}
+}
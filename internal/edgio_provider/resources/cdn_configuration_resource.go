@@ -4,23 +4,42 @@ import (
 	"context"
 	"terraform-provider-edgio/internal/edgio_api"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
 	"terraform-provider-edgio/internal/edgio_provider/models"
 	"terraform-provider-edgio/internal/edgio_provider/utility"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &CDNConfigurationResource{}
+	_ resource.ResourceWithValidateConfig = &CDNConfigurationResource{}
+	_ resource.ResourceWithModifyPlan     = &CDNConfigurationResource{}
+	_ resource.ResourceWithUpgradeState   = &CDNConfigurationResource{}
+	_ resource.ResourceWithConfigure      = &CDNConfigurationResource{}
+)
+
 type CDNConfigurationResource struct {
-	client edgio_api.EdgioClientInterface
+	client   edgio_api.EdgioClientInterface
+	features models.ProviderFeatures
+}
+
+func NewCDNConfigurationResource() resource.Resource {
+	return &CDNConfigurationResource{}
 }
 
-func NewCDNConfigurationResource(client edgio_api.EdgioClientInterface) resource.Resource {
-	return &CDNConfigurationResource{
-		client: client,
+func (r *CDNConfigurationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+		r.features = data.Features
 	}
 }
 
@@ -29,7 +48,12 @@ func (r *CDNConfigurationResource) Metadata(_ context.Context, req resource.Meta
 }
 
 func (r *CDNConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
+	resp.Schema = cdnConfigurationSchema()
+}
+
+func cdnConfigurationSchema() schema.Schema {
+	return schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"environment_id": schema.StringAttribute{
 				Required: true,
@@ -39,277 +63,255 @@ func (r *CDNConfigurationResource) Schema(ctx context.Context, req resource.Sche
 				Computed: true,
 			},
 			"rules": schema.StringAttribute{
-				Required: true,
+				CustomType:  customtypes.RulesJSONType{},
+				Optional:    true,
+				Computed:    true,
+				Description: "The CDN rules as a JSON array. Exactly one of `rules` and `rule` must be set, when `rule` blocks are used this holds the compiled JSON.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					JSONEqualityModifier{},
 				},
 			},
 			"origins": schema.ListNestedAttribute{
-				Required: true,
+				Optional:    true,
+				Description: "The origins as a list. Exactly one of `origins` and `origins_by_name` must be set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cdnOriginAttributes(),
+				},
+			},
+			"origins_by_name": schema.MapNestedAttribute{
+				Optional: true,
+				Description: "The origins keyed by origin name, so that their order does not matter. " +
+					"Exactly one of `origins` and `origins_by_name` must be set.",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Required: true,
-						},
-						"type": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-						},
-						"hosts": schema.ListNestedAttribute{
-							Optional: true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"weight": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
-									},
-									"dns_max_ttl": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
-									},
-									"dns_preference": schema.StringAttribute{
-										Optional: true,
-										Computed: true,
-									},
-									"max_hard_pool": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
-									},
-									"dns_min_ttl": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
-									},
-									"location": schema.ListNestedAttribute{
-										Optional: true,
-										Computed: true,
-										NestedObject: schema.NestedAttributeObject{
-											Attributes: map[string]schema.Attribute{
-												"port": schema.Int64Attribute{
-													Optional: true,
-													Computed: true,
-												},
-												"hostname": schema.StringAttribute{
-													Optional: true,
-													Computed: true,
-												},
-											},
-										},
-									},
-									"max_pool": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
-									},
-									"balancer": schema.StringAttribute{
-										Optional: true,
-										Computed: true,
-									},
-									"scheme": schema.StringAttribute{
-										Optional: true,
-										Computed: true,
-									},
-									"override_host_header": schema.StringAttribute{
-										Optional: true,
-										Computed: true,
-									},
-									"sni_hint_and_strict_san_check": schema.StringAttribute{
-										Optional: true,
-										Computed: true,
-									},
-									"use_sni": schema.BoolAttribute{
-										Optional: true,
-										Computed: true,
-									},
-								},
-							},
-						},
-						"balancer": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-						},
-						"override_host_header": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-						},
-						"shields": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"apac": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"emea": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"us_west": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"us_east": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-							},
-						},
-						"pci_certified_shields": schema.BoolAttribute{
-							Optional: true,
-							Computed: true,
-						},
-						"tls_verify": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"use_sni": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"sni_hint_and_strict_san_check": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"allow_self_signed_certs": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"pinned_certs": schema.ListAttribute{
-									ElementType: types.StringType,
-									Optional:    true,
-									Computed:    true,
-								},
-							},
-						},
-						"retry": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"status_codes": schema.ListAttribute{
-									ElementType: types.Int64Type,
-									Optional:    true,
-									Computed:    true,
-								},
-								"ignore_retry_after_header": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"after_seconds": schema.Int64Attribute{
-									Optional: true,
-									Computed: true,
-								},
-								"max_requests": schema.Int64Attribute{
-									Optional: true,
-									Computed: true,
-								},
-								"max_wait_seconds": schema.Int64Attribute{
-									Optional: true,
-									Computed: true,
-								},
-							},
-						},
-					},
+					Attributes: cdnKeyedAttributes(cdnOriginAttributes(), "name", "The origin name, same as the map key."),
 				},
 			},
 			"hostnames": schema.ListNestedAttribute{
-				Required: true,
+				Optional:    true,
+				Description: "The hostnames as a list. Exactly one of `hostnames` and `hostnames_by_name` must be set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cdnHostnameAttributes(),
+				},
+			},
+			"hostnames_by_name": schema.MapNestedAttribute{
+				Optional: true,
+				Description: "The hostnames keyed by hostname, so that their order does not matter. " +
+					"Exactly one of `hostnames` and `hostnames_by_name` must be set.",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"hostname": schema.StringAttribute{
-							Required: true,
-						},
-						"default_origin_name": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-						},
-						"report_code": schema.Int64Attribute{
-							Optional: true,
-							Computed: true,
-						},
-						"tls": schema.SingleNestedAttribute{
-							Optional: true,
-							Computed: true,
-							Attributes: map[string]schema.Attribute{
-								"npn": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"alpn": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"protocols": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"use_sigalgs": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"sni": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"sni_strict": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"sni_host_match": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"client_renegotiation": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"options": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"cipher_list": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"named_curve": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"oscp": schema.BoolAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"pem": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-								"ca": schema.StringAttribute{
-									Optional: true,
-									Computed: true,
-								},
-							},
-						},
-						"directory": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-						},
-					},
+					Attributes: cdnKeyedAttributes(cdnHostnameAttributes(), "hostname", "The hostname, same as the map key."),
 				},
 			},
 			"experiments": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"edge_functions_sources": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"edge_function_init_script": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"edge_functions_directory": schema.StringAttribute{
+				Optional: true,
+				Description: "Directory with the edge function sources, read at plan time. Every file below the directory is uploaded, " +
+					"keyed by its path relative to the directory. Conflicts with `edge_functions_sources`.",
+			},
+			"edge_function_init_script_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "File with the edge function init script, read at plan time. Conflicts with `edge_function_init_script`.",
+			},
+			"edge_functions_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the edge function sources and the init script. Changes whenever any of them changes.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules_lint": schema.StringAttribute{
+				Optional: true,
+				Description: "How findings of the rules linter (duplicate rules and conditions, unreachable rules, undefined origins, " +
+					"contradicting caching features) and violations of the embedded rules schema are reported. " +
+					"Possible values: `off`, `warn`, `error`. Defaults to `warn`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": cdnRuleBlock(),
+		},
+	}
+}
+
+func (r *CDNConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := cdnConfigurationSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior models.CDNConfigurationModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state := utility.ConvertCdnConfigV0(&prior)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 			},
 		},
 	}
 }
 
+func (r *CDNConfigurationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rules customtypes.RulesJSON
+	var rule types.List
+	var lint types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rule"), &rule)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules_lint"), &lint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateExactlyOneOf(ctx, req.Config, &resp.Diagnostics, "origins", "origins_by_name")
+	validateExactlyOneOf(ctx, req.Config, &resp.Diagnostics, "hostnames", "hostnames_by_name")
+	validateAtMostOneOf(ctx, req.Config, &resp.Diagnostics, "edge_functions_sources", "edge_functions_directory")
+	validateAtMostOneOf(ctx, req.Config, &resp.Diagnostics, "edge_function_init_script", "edge_function_init_script_file")
+	validateCDNConfigReferences(ctx, req.Config, &resp.Diagnostics)
+
+	hasRule := rule.IsUnknown() || len(rule.Elements()) > 0
+
+	if !rules.IsNull() && hasRule {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rule"),
+			"Conflicting Rules Attributes",
+			"Only one of 'rules' and 'rule' can be set.",
+		)
+	}
+
+	if rules.IsNull() && !hasRule {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rules"),
+			"Missing Rules",
+			"One of 'rules' or 'rule' must be set.",
+		)
+	}
+
+	validateRulesLintMode(ctx, req.Config, &resp.Diagnostics)
+	validateRulesSchema(&resp.Diagnostics, path.Root("rules"), lint, rules)
+}
+
+// ModifyPlan fills in the names of keyed origins and hostnames, compiles the
+// structured rule blocks into the planned rules JSON, so that both forms produce
+// the same plan and the same upload, lints the planned rules and plans the edge
+// function sources. It also reports the per-rule diff of changed rules.
+func (r *CDNConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var ruleList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule"), &ruleList)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planKeyAttributes(ctx, &resp.Plan, &resp.Diagnostics, "origins_by_name", "name")
+	planKeyAttributes(ctx, &resp.Plan, &resp.Diagnostics, "hostnames_by_name", "hostname")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rulesPath := path.Root("rules")
+	if len(ruleList.Elements()) > 0 {
+		rulesPath = path.Root("rule")
+		r.compileRuleBlocks(ctx, req, resp, ruleList)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.lintRules(ctx, resp, rulesPath)
+	r.diffRules(ctx, req, resp, rulesPath)
+	planEdgeFunctions(ctx, req, resp, rulesPath)
+}
+
+func (r *CDNConfigurationResource) compileRuleBlocks(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, ruleList types.List) {
+	ruleValue, err := ruleList.ToTerraformValue(ctx)
+	if err != nil || !ruleValue.IsFullyKnown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rules"), customtypes.NewRulesJSONUnknown())...)
+		return
+	}
+
+	var rule []models.RuleModel
+	resp.Diagnostics.Append(ruleList.ElementsAs(ctx, &rule, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := utility.ConvertRulesToJSON(rule)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rule"), "Invalid Rule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rules"), customtypes.NewRulesJSONValue(rules))...)
+
+	// Rules set as JSON are validated in ValidateConfig, compiled rule blocks
+	// are only known here.
+	var lint types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules_lint"), &lint)...)
+	validateRulesSchema(&resp.Diagnostics, path.Root("rule"), lint, customtypes.NewRulesJSONValue(rules))
+}
+
+// diffRules reports which rules change between the prior state and the plan,
+// because the plan itself only shows the whole minified rules string. It is
+// turned off by the rules_diff_warnings provider feature.
+func (r *CDNConfigurationResource) diffRules(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, rulesPath path.Path) {
+	if !r.features.RulesDiffWarnings || req.State.Raw.IsNull() {
+		return
+	}
+
+	var prior, planned customtypes.RulesJSON
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rules"), &prior)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules"), &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addRulesDiffWarning(&resp.Diagnostics, rulesPath, prior.StringValue, planned.StringValue)
+}
+
+// lintRules lints the planned rules against the planned origins.
+func (r *CDNConfigurationResource) lintRules(ctx context.Context, resp *resource.ModifyPlanResponse, rulesPath path.Path) {
+	var rules customtypes.RulesJSON
+	var lint types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules_lint"), &lint)...)
+	origins := declaredOriginNames(ctx, resp.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addRulesLintFindings(&resp.Diagnostics, rulesPath, lint, rules, origins)
+}
+
 func (r *CDNConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan models.CDNConfigurationModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -318,7 +320,16 @@ func (r *CDNConfigurationResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	cdnConfig := utility.ConvertCdnConfigToNative(&plan)
+	loadEdgeFunctionFiles(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cdnConfig, diags := utility.ConvertCdnConfigToNative(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	cfg, err := r.client.UploadCdnConfiguration(&cdnConfig)
 
 	if err != nil {
@@ -334,11 +345,17 @@ func (r *CDNConfigurationResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	state := utility.ConvertNativeToCdnConfig(status)
+	utility.ArrangeCdnConfigLike(&state, &plan)
+	keepConfigOnlyAttributes(&state, &plan)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *CDNConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state models.CDNConfigurationModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -354,13 +371,20 @@ func (r *CDNConfigurationResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	prior := state
 	state = utility.ConvertNativeToCdnConfig(cdnConfig)
+	utility.ArrangeCdnConfigLike(&state, &prior)
+	keepConfigOnlyAttributes(&state, &prior)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *CDNConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan models.CDNConfigurationModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -369,7 +393,16 @@ func (r *CDNConfigurationResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	cdnConfig := utility.ConvertCdnConfigToNative(&plan)
+	loadEdgeFunctionFiles(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cdnConfig, diags := utility.ConvertCdnConfigToNative(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	cfg, err := r.client.UploadCdnConfiguration(&cdnConfig)
 
@@ -386,9 +419,20 @@ func (r *CDNConfigurationResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	state := utility.ConvertNativeToCdnConfig(status)
+	utility.ArrangeCdnConfigLike(&state, &plan)
+	keepConfigOnlyAttributes(&state, &plan)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// keepConfigOnlyAttributes copies the attributes the API does not know about
+// from the plan or the prior state.
+func keepConfigOnlyAttributes(state *models.CDNConfigurationModel, source *models.CDNConfigurationModel) {
+	state.Rule = source.Rule
+	state.RulesLint = source.RulesLint
+	state.EdgeFunctionsDirectory = source.EdgeFunctionsDirectory
+	state.EdgeFunctionInitScriptFile = source.EdgeFunctionInitScriptFile
+}
+
 func (r *CDNConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
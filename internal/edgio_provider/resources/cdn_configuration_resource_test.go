@@ -1,7 +1,12 @@
 package resources_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"terraform-provider-edgio/internal/edgio_api"
@@ -11,7 +16,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -151,3 +160,964 @@ func TestCDNConfigurationResource_Lifecycle(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestCDNConfigurationResource_StructuredRules(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	expectedRules := `[{"if":[{"and":[{"==":[{"request":"path"},"/api/:path*"]},{"in":[{"request":"method"},["GET","HEAD"]]}]},` +
+		`{"caching":{"max_age":{"200":"1h"}},"origin":{"set_origin":"origin-1"}},{"else":{"caching":{"bypass_cache":true}}}]},` +
+		`{"headers":{"set_response_headers":{"x-powered-by":"edgio"}}}]`
+
+	cdnConfig := &dtos.CDNConfiguration{
+		ConfigurationID: "config-456",
+		EnvironmentID:   "env-123",
+		Rules:           json.RawMessage(expectedRules),
+		Origins: []dtos.Origin{
+			{
+				Name: "origin-1",
+				Hosts: []dtos.Host{
+					{
+						Location: utility.ToPtr([]dtos.Location{
+							{
+								Port:     utility.ToPtr(int64(443)),
+								Hostname: utility.ToPtr("origin.example.com"),
+							},
+						}),
+					},
+				},
+			},
+		},
+		Hostnames: []dtos.Hostname{
+			{
+				Hostname:          utility.ToPtr("cdn.example.com"),
+				DefaultOriginName: utility.ToPtr("origin-1"),
+				TLS: &dtos.TLS{
+					SNI: utility.ToPtr(true),
+				},
+			},
+		},
+	}
+
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return string(config.Rules) == expectedRules
+	})).Return(cdnConfig, nil)
+	mockClient.On("GetCDNConfiguration", "config-456").Return(cdnConfig, nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+
+					rule {
+						condition {
+							variable = "path"
+							value    = "/api/:path*"
+						}
+						condition {
+							variable = "method"
+							operator = "in"
+							values   = ["GET", "HEAD"]
+						}
+						features {
+							caching {
+								max_age = { "200" = "1h" }
+							}
+							origin {
+								set_origin = "origin-1"
+							}
+						}
+						else {
+							caching {
+								bypass_cache = true
+							}
+						}
+					}
+
+					rule {
+						features {
+							headers {
+								set_response_headers = { "x-powered-by" = "edgio" }
+							}
+						}
+					}
+
+					origins = [{
+						name  = "origin-1"
+						hosts = [{
+							location = [{
+								port     = 443
+								hostname = "origin.example.com"
+							}]
+						}]
+					}]
+
+					hostnames = [{
+						hostname            = "cdn.example.com"
+						default_origin_name = "origin-1"
+						tls = {
+							sni = true
+						}
+					}]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "configuration_id", "config-456"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "rules", expectedRules),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "rule.#", "2"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_InvalidRules(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "error"
+					rules = jsonencode([
+						{ "caching": { "max_age": { "200": "1h" } } },
+						{ "response": { "set_status_code": 700 } }
+					])
+
+					origins = [{
+						name  = "origin-1"
+						hosts = [{
+							location = [{
+								port     = 443
+								hostname = "origin.example.com"
+							}]
+						}]
+					}]
+
+					hostnames = [{
+						hostname            = "cdn.example.com"
+						default_origin_name = "origin-1"
+					}]
+				}`,
+				ExpectError: regexp.MustCompile(`/1/response/set_status_code`),
+			},
+			{
+				// Compiled rule blocks are validated at plan time.
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "error"
+
+					rule {
+						features {
+							caching {
+								max_age = { "200" = "1h" }
+							}
+						}
+					}
+
+					rule {
+						features {
+							caching {
+								client_max_age = "one hour"
+							}
+						}
+					}
+
+					origins   = [{ name = "origin-1", hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }] }]
+					hostnames = [{ hostname = "cdn.example.com", default_origin_name = "origin-1" }]
+				}`,
+				ExpectError: regexp.MustCompile(`/1/caching/client_max_age`),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_CanonicalRules(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	canonicalRules := `[{"caching":{"max_age":{"200":"1h"}},"response":{"set_status_code":404}}]`
+
+	cdnConfig := &dtos.CDNConfiguration{
+		ConfigurationID: "config-789",
+		EnvironmentID:   "env-123",
+		Rules:           json.RawMessage(canonicalRules),
+		Origins: []dtos.Origin{
+			{
+				Name: "origin-1",
+				Hosts: []dtos.Host{
+					{
+						Location: utility.ToPtr([]dtos.Location{
+							{
+								Port:     utility.ToPtr(int64(443)),
+								Hostname: utility.ToPtr("origin.example.com"),
+							},
+						}),
+					},
+				},
+			},
+		},
+		Hostnames: []dtos.Hostname{
+			{
+				Hostname:          utility.ToPtr("cdn.example.com"),
+				DefaultOriginName: utility.ToPtr("origin-1"),
+				TLS: &dtos.TLS{
+					SNI: utility.ToPtr(true),
+				},
+			},
+		},
+	}
+
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		rules, err := utility.MinifyJSON(string(config.Rules))
+		return err == nil && rules == canonicalRules
+	})).Return(cdnConfig, nil)
+	mockClient.On("GetCDNConfiguration", "config-789").Return(cdnConfig, nil)
+
+	config := func(rules string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_cdn_configuration" "test" {
+			environment_id = "env-123"
+			rules          = %q
+
+			origins = [{
+				name  = "origin-1"
+				hosts = [{
+					location = [{
+						port     = 443
+						hostname = "origin.example.com"
+					}]
+				}]
+			}]
+
+			hostnames = [{
+				hostname            = "cdn.example.com"
+				default_origin_name = "origin-1"
+				tls = {
+					sni = true
+				}
+			}]
+		}`, rules)
+	}
+
+	rules := `[ { "response": { "set_status_code": 4.04e2 }, "caching": { "max_age": { "200": "1h" } } } ]`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				// The API returns the rules in canonical form, the configured
+				// formatting is kept in state.
+				Config: config(rules),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "rules", rules),
+				),
+			},
+			{
+				// Key order, whitespace and number formatting must not show up as drift.
+				Config:   config(rules),
+				PlanOnly: true,
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_RulesLint(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "error"
+					rules = jsonencode([
+						{ "if" : [{ "==" : [{ "request" : "path" }, "/api/:path*"] }, { "caching" : { "bypass_cache" : true, "max_age" : { "200" : "1h" } } }] },
+						{ "origin" : { "set_origin" : "edgio_serverless" } }
+					])
+
+					origins = [{
+						name  = "origin-1"
+						hosts = [{
+							location = [{
+								port     = 443
+								hostname = "origin.example.com"
+							}]
+						}]
+					}]
+
+					hostnames = [{
+						hostname            = "cdn.example.com"
+						default_origin_name = "origin-1"
+					}]
+				}`,
+				ExpectError: regexp.MustCompile(`max_age has no effect because bypass_cache`),
+			},
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "error"
+					rules          = jsonencode([{ "origin" : { "set_origin" : "missing-origin" } }])
+
+					origins_by_name = {
+						"origin-1" = {
+							hosts = [{
+								location = [{
+									port     = 443
+									hostname = "origin.example.com"
+								}]
+							}]
+						}
+					}
+
+					hostnames = [{
+						hostname            = "cdn.example.com"
+						default_origin_name = "origin-1"
+					}]
+				}`,
+				// Undefined origins fail the validation before the linter runs.
+				ExpectError: regexp.MustCompile(`origin 'missing-origin' is not defined`),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_InvalidReferences(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+
+					rule {
+						features {
+							origin {
+								set_origin = "missing-origin"
+							}
+						}
+					}
+
+					origins = [
+						{
+							name  = "origin-1"
+							hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+						},
+						{
+							name  = "origin-1"
+							hosts = [{ location = [{ port = 443, hostname = "backup.example.com" }] }]
+						}
+					]
+
+					hostnames = [
+						{
+							hostname            = "cdn.example.com"
+							default_origin_name = "origin-2"
+						},
+						{
+							hostname = "CDN.example.com"
+						},
+						{
+							hostname = "not a hostname"
+						}
+					]
+				}`,
+				ExpectError: regexp.MustCompile(`Origin name 'origin-1' is used by more than one origin`),
+			},
+			{
+				// Undefined rule origins are errors whatever the rules_lint mode.
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "off"
+
+					rule {
+						features {
+							origin {
+								set_origin = "origin-1"
+							}
+						}
+						else {
+							origin {
+								set_origin = "missing-origin"
+							}
+						}
+					}
+
+					origins   = [{ name = "origin-1", hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }] }]
+					hostnames = [{ hostname = "cdn.example.com", default_origin_name = "origin-1" }]
+				}`,
+				ExpectError: regexp.MustCompile(`Origin 'missing-origin' is not defined in origins`),
+			},
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration" "test" {
+					environment_id = "env-123"
+					rules_lint     = "off"
+					rules          = jsonencode([{ "origin" : { "set_origin" : "origin-1" } }, { "origin" : { "set_origin" : "missing-origin" } }])
+
+					origins   = [{ name = "origin-1", hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }] }]
+					hostnames = [{ hostname = "cdn.example.com", default_origin_name = "origin-1" }]
+				}`,
+				ExpectError: regexp.MustCompile(`/1/origin/set_origin: origin 'missing-origin' is not`),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_KeyedByName(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	location := func(hostname string) *[]dtos.Location {
+		return utility.ToPtr([]dtos.Location{{Port: utility.ToPtr(int64(443)), Hostname: utility.ToPtr(hostname)}})
+	}
+
+	// The API returns origins and hostnames in a different order than configured.
+	cdnConfig := &dtos.CDNConfiguration{
+		ConfigurationID: "config-keyed",
+		EnvironmentID:   "env-123",
+		Rules:           json.RawMessage(`[{"origin":{"set_origin":"web"}}]`),
+		Origins: []dtos.Origin{
+			{Name: "web", Hosts: []dtos.Host{{Location: location("web.example.com")}}},
+			{Name: "api", Hosts: []dtos.Host{{Location: location("api.example.com")}}},
+		},
+		Hostnames: []dtos.Hostname{
+			{Hostname: utility.ToPtr("www.example.com"), DefaultOriginName: utility.ToPtr("web"), TLS: &dtos.TLS{SNI: utility.ToPtr(true)}},
+			{Hostname: utility.ToPtr("api.example.com"), DefaultOriginName: utility.ToPtr("api"), TLS: &dtos.TLS{SNI: utility.ToPtr(true)}},
+		},
+	}
+
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return len(config.Origins) == 2 && config.Origins[0].Name == "api" && config.Origins[1].Name == "web"
+	})).Return(cdnConfig, nil)
+	mockClient.On("GetCDNConfiguration", "config-keyed").Return(cdnConfig, nil)
+
+	config := `
+	provider "edgio" {
+		client_id     = "mock-client-id"
+		client_secret = "mock-client-secret"
+	}
+
+	resource "edgio_cdn_configuration" "test" {
+		environment_id = "env-123"
+		rules          = jsonencode([{ "origin" : { "set_origin" : "web" } }])
+
+		origins_by_name = {
+			web = {
+				hosts = [{ location = [{ port = 443, hostname = "web.example.com" }] }]
+			}
+			api = {
+				hosts = [{ location = [{ port = 443, hostname = "api.example.com" }] }]
+			}
+		}
+
+		hostnames_by_name = {
+			"www.example.com" = { default_origin_name = "web", tls = { sni = true } }
+			"api.example.com" = { default_origin_name = "api", tls = { sni = true } }
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "origins_by_name.%", "2"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "origins_by_name.web.name", "web"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "origins_by_name.api.hosts.0.location.0.hostname", "api.example.com"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "hostnames_by_name.%", "2"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "hostnames_by_name.www.example.com.default_origin_name", "web"),
+					resource.TestCheckNoResourceAttr("edgio_cdn_configuration.test", "origins.#"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_APIDefaults(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	// The API fills in its defaults for everything the configuration leaves unset.
+	cdnConfig := &dtos.CDNConfiguration{
+		ConfigurationID: "config-defaults",
+		EnvironmentID:   "env-123",
+		Rules:           json.RawMessage(`[{"origin":{"set_origin":"origin-1"}}]`),
+		Origins: []dtos.Origin{
+			{
+				Name: "origin-1",
+				Type: utility.ToPtr("customer_origin"),
+				Hosts: []dtos.Host{
+					{
+						Weight:        utility.ToPtr(int64(200)),
+						DNSMaxTTL:     utility.ToPtr(int64(3600)),
+						DNSMinTTL:     utility.ToPtr(int64(600)),
+						DNSPreference: utility.ToPtr("prefv4"),
+						MaxHardPool:   utility.ToPtr(int64(10)),
+						MaxPool:       utility.ToPtr(int64(0)),
+						Scheme:        utility.ToPtr("match"),
+						UseSNI:        utility.ToPtr(false),
+						Location: utility.ToPtr([]dtos.Location{
+							{
+								Port:     utility.ToPtr(int64(443)),
+								Hostname: utility.ToPtr("origin.example.com"),
+							},
+						}),
+					},
+				},
+				Balancer:            utility.ToPtr("round_robin"),
+				PciCertifiedShields: utility.ToPtr(false),
+			},
+		},
+		Hostnames: []dtos.Hostname{
+			{
+				Hostname:          utility.ToPtr("cdn.example.com"),
+				DefaultOriginName: utility.ToPtr("origin-1"),
+				TLS: &dtos.TLS{
+					NPN:       utility.ToPtr(true),
+					ALPN:      utility.ToPtr(true),
+					Protocols: utility.ToPtr("TLSv1.2 TLSv1.3"),
+					SNI:       utility.ToPtr(true),
+				},
+			},
+		},
+	}
+
+	mockClient.On("UploadCdnConfiguration", mock.AnythingOfType("*dtos.CDNConfiguration")).
+		Run(func(args mock.Arguments) {
+			cdnConfig.Rules = args.Get(0).(*dtos.CDNConfiguration).Rules
+		}).
+		Return(cdnConfig, nil)
+	mockClient.On("GetCDNConfiguration", "config-defaults").Return(cdnConfig, nil)
+
+	config := func(origin string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_cdn_configuration" "test" {
+			environment_id = "env-123"
+			rules          = jsonencode([{ "origin" : { "set_origin" : %q } }])
+
+			origins = [{
+				name  = "origin-1"
+				hosts = [{
+					location = [{
+						port     = 443
+						hostname = "origin.example.com"
+					}]
+				}]
+			}]
+
+			hostnames = [{
+				hostname            = "cdn.example.com"
+				default_origin_name = "origin-1"
+			}]
+		}`, origin)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("origin-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("edgio_cdn_configuration.test",
+							tfjsonpath.New("origins").AtSliceIndex(0).AtMapKey("hosts").AtSliceIndex(0).AtMapKey("weight"),
+							knownvalue.Int64Exact(200)),
+						plancheck.ExpectKnownValue("edgio_cdn_configuration.test",
+							tfjsonpath.New("origins").AtSliceIndex(0).AtMapKey("balancer"),
+							knownvalue.StringExact("round_robin")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "origins.0.type", "customer_origin"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "hostnames.0.tls.protocols", "TLSv1.2 TLSv1.3"),
+				),
+			},
+			{
+				// An unchanged configuration must not plan anything.
+				Config: config("origin-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// Changing the rules keeps the values filled in by the API known.
+				Config: config("edgio_static"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("edgio_cdn_configuration.test",
+							tfjsonpath.New("origins").AtSliceIndex(0).AtMapKey("type"),
+							knownvalue.StringExact("customer_origin")),
+						plancheck.ExpectKnownValue("edgio_cdn_configuration.test",
+							tfjsonpath.New("hostnames").AtSliceIndex(0).AtMapKey("tls").AtMapKey("sni"),
+							knownvalue.Bool(true)),
+					},
+				},
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCDNConfigurationResource_EdgeFunctionsDirectory(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	directory := t.TempDir()
+	writeFile := func(name, content string) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(directory, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("main.js", "export function handleHttpRequest(request, context) {}")
+	writeFile("lib/util.js", "export const answer = 42")
+	writeFile(".eslintrc", "{}")
+	initScript := filepath.Join(t.TempDir(), "init.js")
+	if err := os.WriteFile(initScript, []byte("globalThis.ready = true"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := map[string]string{
+		"main.js":     "export function handleHttpRequest(request, context) {}",
+		"lib/util.js": "export const answer = 42",
+	}
+
+	cdnConfig := &dtos.CDNConfiguration{
+		ConfigurationID:        "config-edge",
+		EnvironmentID:          "env-123",
+		Rules:                  json.RawMessage(`[{"edge_function":"./main.js"}]`),
+		EdgeFunctionsSources:   &sources,
+		EdgeFunctionInitScript: utility.ToPtr("globalThis.ready = true"),
+		Origins: []dtos.Origin{
+			{Name: "origin-1", Hosts: []dtos.Host{{Location: utility.ToPtr([]dtos.Location{{Port: utility.ToPtr(int64(443)), Hostname: utility.ToPtr("origin.example.com")}})}}},
+		},
+		Hostnames: []dtos.Hostname{
+			{Hostname: utility.ToPtr("cdn.example.com"), DefaultOriginName: utility.ToPtr("origin-1"), TLS: &dtos.TLS{SNI: utility.ToPtr(true)}},
+		},
+	}
+
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EdgeFunctionsSources != nil && len(*config.EdgeFunctionsSources) == 2 &&
+			(*config.EdgeFunctionsSources)["lib/util.js"] == sources["lib/util.js"] &&
+			config.EdgeFunctionInitScript != nil && *config.EdgeFunctionInitScript == "globalThis.ready = true"
+	})).Return(cdnConfig, nil)
+	mockClient.On("GetCDNConfiguration", "config-edge").Return(cdnConfig, nil)
+
+	config := func(edgeFunction string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_cdn_configuration" "test" {
+			environment_id                 = "env-123"
+			rules                          = jsonencode([{ "edge_function" : %q }])
+			edge_functions_directory       = %q
+			edge_function_init_script_file = %q
+
+			origins = [{
+				name  = "origin-1"
+				hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+			}]
+
+			hostnames = [{
+				hostname            = "cdn.example.com"
+				default_origin_name = "origin-1"
+				tls                 = { sni = true }
+			}]
+		}`, edgeFunction, directory, initScript)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("./main.js"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "edge_functions_sources.%", "2"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration.test", "edge_functions_hash",
+						utility.EdgeFunctionsHash(sources, "globalThis.ready = true")),
+				),
+			},
+			{
+				Config:      config("./missing.js"),
+				ExpectError: regexp.MustCompile(`Missing Edge Function Source`),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+// TestCDNConfigurationResource_UpgradeStateV0 upgrades a state written by the
+// provider before the schema was versioned.
+func TestCDNConfigurationResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(new(edgio_api.MockEdgioClient)))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	priorState := `{
+		"configuration_id": "config-123",
+		"environment_id": "env-123",
+		"rules": "[{\"response\": {\"set_status_code\": 4.04e2}}]",
+		"origins": [{
+			"name": "origin-1",
+			"type": "customer_origin",
+			"hosts": [{"weight": 200, "location": [{"port": 443, "hostname": "origin.example.com"}]}],
+			"balancer": "round_robin"
+		}],
+		"hostnames": [{"hostname": "cdn.example.com", "default_origin_name": "origin-1", "tls": {"protocols": "TLSv1.2"}}],
+		"experiments": [],
+		"edge_functions_sources": {},
+		"edge_function_init_script": null
+	}`
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "edgio_cdn_configuration",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(priorState)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgraded, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas["edgio_cdn_configuration"].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := upgraded.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	var rules string
+	if err := attributes["rules"].As(&rules); err != nil {
+		t.Fatal(err)
+	}
+	if rules != `[{"response":{"set_status_code":404}}]` {
+		t.Errorf("expected canonical rules, got %s", rules)
+	}
+
+	var origins []tftypes.Value
+	if err := attributes["origins"].As(&origins); err != nil {
+		t.Fatal(err)
+	}
+	if len(origins) != 1 {
+		t.Errorf("expected the origins list to be kept, got %d origins", len(origins))
+	}
+
+	for _, name := range []string{"origins_by_name", "hostnames_by_name", "rules_lint"} {
+		if !attributes[name].IsNull() {
+			t.Errorf("expected %s to be null, got %s", name, attributes[name])
+		}
+	}
+	if attributes["edge_functions_hash"].IsNull() {
+		t.Error("expected edge_functions_hash to be set")
+	}
+}
+
+func TestCDNConfigurationResource_RulesDiffWarnings(t *testing.T) {
+	for name, test := range map[string]struct {
+		features    tftypes.Value
+		wantWarning bool
+	}{
+		"default": {
+			features:    tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"rules_diff_warnings": tftypes.Bool}}, nil),
+			wantWarning: true,
+		},
+		"disabled": {
+			features: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"rules_diff_warnings": tftypes.Bool}}, map[string]tftypes.Value{
+				"rules_diff_warnings": tftypes.NewValue(tftypes.Bool, false),
+			}),
+			wantWarning: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			server, err := providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(new(edgio_api.MockEdgioClient)))()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			providerType := schemas.Provider.ValueType().(tftypes.Object)
+			providerAttributes := map[string]tftypes.Value{}
+			for name, attributeType := range providerType.AttributeTypes {
+				providerAttributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			providerAttributes["client_id"] = tftypes.NewValue(tftypes.String, "mock-client-id")
+			providerAttributes["client_secret"] = tftypes.NewValue(tftypes.String, "mock-client-secret")
+			providerAttributes["features"] = test.features
+
+			providerConfig, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, providerAttributes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, diagnostic := range configureResp.Diagnostics {
+				t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+
+			// The current schema version reads the state JSON as it is.
+			state := func(rules string) *tfprotov6.DynamicValue {
+				resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+					TypeName: "edgio_cdn_configuration",
+					Version:  1,
+					RawState: &tfprotov6.RawState{JSON: []byte(fmt.Sprintf(`{
+						"configuration_id": "config-123",
+						"environment_id": "env-123",
+						"rules": %q,
+						"origins": [{"name": "origin-1", "hosts": [{"location": [{"port": 443, "hostname": "origin.example.com"}]}]}],
+						"hostnames": [{"hostname": "cdn.example.com", "default_origin_name": "origin-1"}]
+					}`, rules))},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, diagnostic := range resp.Diagnostics {
+					t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+				return resp.UpgradedState
+			}
+
+			prior := state(`[{"response":{"set_status_code":404}}]`)
+			proposed := state(`[{"response":{"set_status_code":410}}]`)
+
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "edgio_cdn_configuration",
+				PriorState:       prior,
+				ProposedNewState: proposed,
+				Config:           proposed,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			warned := false
+			for _, diagnostic := range planResp.Diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+				warned = warned || diagnostic.Summary == "CDN Rules Changed"
+			}
+			if warned != test.wantWarning {
+				t.Errorf("expected rules diff warning %t, got %t", test.wantWarning, warned)
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"terraform-provider-edgio/internal/edgio_api"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &CDNConfigurationSetResource{}
+	_ resource.ResourceWithValidateConfig = &CDNConfigurationSetResource{}
+	_ resource.ResourceWithConfigure      = &CDNConfigurationSetResource{}
+)
+
+// CDNConfigurationSetResource uploads one base CDN configuration to several
+// environments, applying the overrides of each environment.
+type CDNConfigurationSetResource struct {
+	client edgio_api.EdgioClientInterface
+}
+
+func NewCDNConfigurationSetResource() resource.Resource {
+	return &CDNConfigurationSetResource{}
+}
+
+func (r *CDNConfigurationSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+	}
+}
+
+func (r *CDNConfigurationSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "edgio_cdn_configuration_set"
+}
+
+func (r *CDNConfigurationSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads one CDN configuration to several environments. Each `environment` block can override the origins, " +
+			"hostnames and experiments of the base configuration.",
+		Attributes: map[string]schema.Attribute{
+			"rules": schema.StringAttribute{
+				CustomType:  customtypes.RulesJSONType{},
+				Required:    true,
+				Description: "The CDN rules as a JSON array, shared by all environments.",
+			},
+			"rules_lint": schema.StringAttribute{
+				Optional: true,
+				Description: "How findings of the rules linter (duplicate rules and conditions, unreachable rules, undefined origins, " +
+					"contradicting caching features) and violations of the embedded rules schema are reported. " +
+					"Possible values: `off`, `warn`, `error`. Defaults to `warn`.",
+			},
+			"origins": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The origins of environments that do not override `origins`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cdnOriginAttributes(),
+				},
+			},
+			"hostnames": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The hostnames of environments that do not override `hostnames`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cdnHostnameAttributes(),
+				},
+			},
+			"experiments": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "The experiments of environments that do not override `experiments`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"edge_functions_sources": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"edge_function_init_script": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "An ID generated when the set is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The ID of the uploaded configuration by environment ID.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"environment": schema.ListNestedBlock{
+				Description: "An environment the configuration is uploaded to. At least one is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"environment_id": schema.StringAttribute{
+							Required: true,
+						},
+						"origins": schema.ListNestedAttribute{
+							Optional:    true,
+							Description: "Replaces the base `origins` for this environment.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: cdnOriginAttributes(),
+							},
+						},
+						"hostnames": schema.ListNestedAttribute{
+							Optional:    true,
+							Description: "Replaces the base `hostnames` for this environment.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: cdnHostnameAttributes(),
+							},
+						},
+						"experiments": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Replaces the base `experiments` for this environment.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *CDNConfigurationSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rules customtypes.RulesJSON
+	var lint types.String
+	var origins, hostnames, environments types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules_lint"), &lint)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("origins"), &origins)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hostnames"), &hostnames)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("environment"), &environments)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateRulesLintMode(ctx, req.Config, &resp.Diagnostics)
+	validateRulesSchema(&resp.Diagnostics, path.Root("rules"), lint, rules)
+
+	if environments.IsUnknown() {
+		return
+	}
+
+	if len(environments.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Missing Environment",
+			"At least one 'environment' block must be set.",
+		)
+		return
+	}
+
+	environmentIDs := map[string]bool{}
+
+	for i, environment := range environments.Elements() {
+		environmentPath := path.Root("environment").AtListIndex(i)
+
+		if id, ok := objectAttribute(environment, "environment_id").(types.String); ok && !id.IsNull() && !id.IsUnknown() {
+			if environmentIDs[id.ValueString()] {
+				resp.Diagnostics.AddAttributeError(
+					environmentPath.AtName("environment_id"),
+					"Duplicate Environment",
+					fmt.Sprintf("Environment '%s' is used by more than one environment block.", id.ValueString()),
+				)
+			}
+			environmentIDs[id.ValueString()] = true
+		}
+
+		for _, override := range []struct {
+			name string
+			base types.List
+		}{{"origins", origins}, {"hostnames", hostnames}} {
+			value := objectAttribute(environment, override.name)
+			if override.base.IsNull() && (value == nil || value.IsNull()) {
+				resp.Diagnostics.AddAttributeError(
+					environmentPath.AtName(override.name),
+					"Missing Attribute",
+					fmt.Sprintf("Either the base '%s' or the '%s' of this environment must be set.", override.name, override.name),
+				)
+			}
+		}
+
+		// Every environment is validated and linted with the origins and
+		// hostnames it is uploaded with.
+		environmentOrigins, originsKnown := environmentElements(environment, environmentPath, origins, "origins", "name")
+		environmentHostnames, _ := environmentElements(environment, environmentPath, hostnames, "hostnames", "hostname")
+		validateNamedElements(&resp.Diagnostics, environmentOrigins, originsKnown, environmentHostnames)
+		validateRulesOrigins(&resp.Diagnostics, elementNames(environmentOrigins, originsKnown), rules)
+		addRulesLintFindings(&resp.Diagnostics, path.Root("rules"), lint, rules, elementNames(environmentOrigins, originsKnown))
+	}
+
+	// Base origins and hostnames are filled from the API response of the
+	// environments using them, so they must be used by at least one.
+	validateBaseUsed(&resp.Diagnostics, origins, environments, "origins")
+	validateBaseUsed(&resp.Diagnostics, hostnames, environments, "hostnames")
+}
+
+// environmentElements returns the origins or hostnames the environment is
+// uploaded with, its override if set, otherwise the base ones, and false if they
+// are not known yet.
+func environmentElements(environment attr.Value, environmentPath path.Path, base types.List, name, key string) ([]namedElement, bool) {
+	if override, ok := objectAttribute(environment, name).(types.List); ok && !override.IsNull() {
+		return listElements(override, environmentPath.AtName(name), key), !override.IsUnknown()
+	}
+
+	return listElements(base, path.Root(name), key), !base.IsUnknown()
+}
+
+func validateBaseUsed(diags *diag.Diagnostics, base types.List, environments types.List, name string) {
+	if base.IsNull() {
+		return
+	}
+
+	for _, environment := range environments.Elements() {
+		value := objectAttribute(environment, name)
+		if value == nil || value.IsNull() || value.IsUnknown() {
+			return
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root(name),
+		"Unused Attribute",
+		fmt.Sprintf("Every environment overrides '%s', the base '%s' must not be set.", name, name),
+	)
+}
+
+func (r *CDNConfigurationSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.CDNConfigurationSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := r.upload(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		if len(state.ConfigurationIDs.Elements()) > 0 {
+			savePartialState(ctx, &state, resp)
+		}
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *CDNConfigurationSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.CDNConfigurationSetModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configurationIDs := utility.MapValueToStringMap(state.ConfigurationIDs)
+
+	for i, environment := range state.Environments {
+		configurationID, ok := configurationIDs[environment.EnvironmentID.ValueString()]
+		if !ok {
+			// Not uploaded yet, the next apply uploads it.
+			continue
+		}
+
+		cdnConfig, err := r.client.GetCDNConfiguration(configurationID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading CDN configuration",
+				fmt.Sprintf("Environment %s: %s", environment.EnvironmentID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		storeEnvironmentConfig(&state, i, utility.ConvertNativeToCdnConfig(cdnConfig), &resp.Diagnostics)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *CDNConfigurationSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.CDNConfigurationSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := r.upload(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// upload uploads the configuration of every environment and returns the state
+// built from the configurations returned by the API. After an error the state
+// holds the configuration IDs of the environments uploaded so far.
+func (r *CDNConfigurationSetResource) upload(ctx context.Context, plan *models.CDNConfigurationSetModel, diags *diag.Diagnostics) models.CDNConfigurationSetModel {
+	state := *plan
+	state.Environments = slices.Clone(plan.Environments)
+	configurationIDs := map[string]attr.Value{}
+
+	// The ID is generated on creation, or on the next update of sets created
+	// before the id attribute existed.
+	if state.ID.IsUnknown() {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			diags.AddError("Error generating ID", err.Error())
+			return state
+		}
+		state.ID = types.StringValue(id)
+	}
+
+	for i, environment := range plan.Environments {
+		config := utility.CdnConfigForEnvironment(plan, i)
+		cdnConfig, d := utility.ConvertCdnConfigToNative(&config)
+		diags.Append(d...)
+		if diags.HasError() {
+			break
+		}
+
+		cfg, err := r.client.UploadCdnConfiguration(&cdnConfig)
+		if err != nil {
+			diags.AddError(
+				"Error creating CDN configuration",
+				fmt.Sprintf("Environment %s: %s", environment.EnvironmentID.ValueString(), err.Error()),
+			)
+			break
+		}
+		configurationIDs[environment.EnvironmentID.ValueString()] = types.StringValue(cfg.ConfigurationID)
+
+		status, err := r.client.GetCDNConfiguration(cfg.ConfigurationID)
+		if err != nil {
+			diags.AddError(
+				"Error reading CDN configuration",
+				fmt.Sprintf("Environment %s: %s", environment.EnvironmentID.ValueString(), err.Error()),
+			)
+			break
+		}
+
+		storeEnvironmentConfig(&state, i, utility.ConvertNativeToCdnConfig(status), diags)
+	}
+
+	// Experiments overridden by every environment are not taken from any.
+	if state.Experiments.IsUnknown() {
+		state.Experiments = types.ListNull(types.StringType)
+	}
+
+	ids, d := types.MapValue(types.StringType, configurationIDs)
+	diags.Append(d...)
+	state.ConfigurationIDs = ids
+
+	return state
+}
+
+// savePartialState stores the state of a set whose creation failed after some
+// environments were uploaded, so that their configuration IDs are tracked and
+// Terraform replaces the tainted set on the next apply. Values that are still
+// unknown are stored as null.
+func savePartialState(ctx context.Context, state *models.CDNConfigurationSetModel, resp *resource.CreateResponse) {
+	diags := resp.State.Set(ctx, state)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	raw, err := tftypes.Transform(resp.State.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error saving partial state", err.Error())
+		return
+	}
+	resp.State.Raw = raw
+}
+
+// storeEnvironmentConfig stores the configuration returned for the i-th
+// environment in the set and warns if it differs from the base configuration
+// taken from an earlier environment.
+func storeEnvironmentConfig(set *models.CDNConfigurationSetModel, i int, config models.CDNConfigurationModel, diags *diag.Diagnostics) {
+	differences := utility.StoreCdnConfigInSet(set, i, config)
+	if len(differences) == 0 {
+		return
+	}
+
+	diags.AddWarning(
+		"CDN Configuration Differs From Base",
+		fmt.Sprintf("The configuration of environment %s returned by the API differs from the base configuration in %s. "+
+			"The base configuration holds the values returned for the first environment using it.",
+			set.Environments[i].EnvironmentID.ValueString(), strings.Join(differences, ", ")),
+	)
+}
+
+// Delete only removes the set from the state, like edgio_cdn_configuration the
+// uploaded configurations stay active on their environments.
+func (r *CDNConfigurationSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var configurationIDs types.Map
+	diags := req.State.GetAttribute(ctx, path.Root("configuration_ids"), &configurationIDs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentIDs := slices.Sorted(maps.Keys(utility.MapValueToStringMap(configurationIDs)))
+	if len(environmentIDs) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Configurations Not Removed",
+		fmt.Sprintf("Destroying edgio_cdn_configuration_set does not remove the uploaded configurations, they stay active on environments %s.",
+			strings.Join(environmentIDs, ", ")),
+	)
+}
@@ -0,0 +1,339 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockCDNConfigurationUpload echoes the uploaded configuration of the
+// environment back as configuration configurationID.
+func mockCDNConfigurationUpload(mockClient *edgio_api.MockEdgioClient, environmentID, configurationID string) {
+	uploaded := &dtos.CDNConfiguration{}
+
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EnvironmentID == environmentID
+	})).
+		Run(func(args mock.Arguments) {
+			*uploaded = *args.Get(0).(*dtos.CDNConfiguration)
+			uploaded.ConfigurationID = configurationID
+		}).
+		Return(uploaded, nil)
+	mockClient.On("GetCDNConfiguration", configurationID).Return(uploaded, nil)
+}
+
+func TestCDNConfigurationSetResource(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+	mockCDNConfigurationUpload(mockClient, "env-staging", "config-staging")
+	mockCDNConfigurationUpload(mockClient, "env-production", "config-production")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration_set" "test" {
+					rules = jsonencode([{ "headers" : { "set_response_headers" : { "x-test" : "123" } } }])
+
+					origins = [{
+						name  = "origin-1"
+						hosts = [{ location = [{ port = 443, hostname = "staging.origin.example.com" }] }]
+					}]
+
+					hostnames = [{
+						hostname            = "staging.example.com"
+						default_origin_name = "origin-1"
+					}]
+
+					environment {
+						environment_id = "env-staging"
+					}
+
+					environment {
+						environment_id = "env-production"
+						experiments    = ["checkout"]
+
+						origins = [{
+							name  = "origin-1"
+							hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+						}]
+
+						hostnames = [{
+							hostname            = "www.example.com"
+							default_origin_name = "origin-1"
+						}]
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("edgio_cdn_configuration_set.test", "id"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "configuration_ids.env-staging", "config-staging"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "configuration_ids.env-production", "config-production"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "hostnames.0.hostname", "staging.example.com"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "environment.1.hostnames.0.hostname", "www.example.com"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "environment.1.origins.0.hosts.0.weight", "200"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "environment.1.experiments.0", "checkout"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertCalled(t, "UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EnvironmentID == "env-production" && *config.Hostnames[0].Hostname == "www.example.com" &&
+			config.Experiments != nil && (*config.Experiments)[0] == "checkout"
+	}))
+	mockClient.AssertCalled(t, "UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EnvironmentID == "env-staging" && *config.Hostnames[0].Hostname == "staging.example.com"
+	}))
+}
+
+// TestCDNConfigurationSetResource_PartialCreate checks that environments
+// uploaded before a failed upload stay tracked and the set is replaced.
+func TestCDNConfigurationSetResource_PartialCreate(t *testing.T) {
+	fake := edgiofake.NewClient()
+	property, err := fake.CreateProperty(context.Background(), "org-123", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	staging, err := fake.CreateEnvironment(property.Id, "staging", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	production, err := fake.CreateEnvironment(property.Id, "production", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(environmentID string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_cdn_configuration_set" "test" {
+			rules = jsonencode([])
+
+			origins = [{
+				name  = "origin-1"
+				hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+			}]
+
+			hostnames = [{
+				hostname            = "www.example.com"
+				default_origin_name = "origin-1"
+			}]
+
+			environment {
+				environment_id = %q
+			}
+
+			environment {
+				environment_id = %q
+			}
+		}`, staging.Id, environmentID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(fake)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config("env-unknown"),
+				ExpectError: regexp.MustCompile(`Environment env-unknown`),
+			},
+			{
+				Config: config(production.Id),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_cdn_configuration_set.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("edgio_cdn_configuration_set.test", "configuration_ids."+staging.Id),
+					resource.TestCheckResourceAttrSet("edgio_cdn_configuration_set.test", "configuration_ids."+production.Id),
+				),
+			},
+		},
+	})
+}
+
+func TestCDNConfigurationSetResource_MissingOrigins(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(new(edgio_api.MockEdgioClient))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration_set" "test" {
+					rules = jsonencode([])
+
+					hostnames = [{
+						hostname            = "staging.example.com"
+						default_origin_name = "origin-1"
+					}]
+
+					environment {
+						environment_id = "env-staging"
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`Either the base 'origins' or the 'origins' of`),
+			},
+		},
+	})
+}
+
+// TestCDNConfigurationSetResource_BaseDifferences checks that the base
+// configuration is not overwritten by the response of a later environment.
+func TestCDNConfigurationSetResource_BaseDifferences(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+	mockCDNConfigurationUpload(mockClient, "env-staging", "config-staging")
+
+	production := &dtos.CDNConfiguration{}
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EnvironmentID == "env-production"
+	})).
+		Run(func(args mock.Arguments) {
+			*production = *args.Get(0).(*dtos.CDNConfiguration)
+			production.ConfigurationID = "config-production"
+			hostnames := slices.Clone(production.Hostnames)
+			hostnames[0].Hostname = utility.ToPtr("changed.example.com")
+			production.Hostnames = hostnames
+		}).
+		Return(production, nil)
+	mockClient.On("GetCDNConfiguration", "config-production").Return(production, nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_cdn_configuration_set" "test" {
+					rules = jsonencode([])
+
+					origins = [{
+						name  = "origin-1"
+						hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+					}]
+
+					hostnames = [{
+						hostname            = "www.example.com"
+						default_origin_name = "origin-1"
+					}]
+
+					environment {
+						environment_id = "env-staging"
+					}
+
+					environment {
+						environment_id = "env-production"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "hostnames.#", "1"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "hostnames.0.hostname", "www.example.com"),
+					resource.TestCheckResourceAttr("edgio_cdn_configuration_set.test", "configuration_ids.env-production", "config-production"),
+				),
+			},
+		},
+	})
+}
+
+func TestCDNConfigurationSetResource_InvalidReferences(t *testing.T) {
+	config := func(rulesLint, defaultOrigin string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_cdn_configuration_set" "test" {
+			rules      = jsonencode([{ "origin" : { "set_origin" : "origin-1" } }])
+			rules_lint = %q
+
+			origins = [{
+				name  = "origin-1"
+				hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+			}]
+
+			hostnames = [{
+				hostname            = "www.example.com"
+				default_origin_name = "origin-1"
+			}]
+
+			environment {
+				environment_id = "env-staging"
+			}
+
+			environment {
+				environment_id = "env-production"
+
+				origins = [{
+					name  = "origin-2"
+					hosts = [{ location = [{ port = 443, hostname = "origin.example.com" }] }]
+				}]
+
+				hostnames = [{
+					hostname            = "www.example.com"
+					default_origin_name = %q
+				}]
+			}
+		}`, rulesLint, defaultOrigin)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(new(edgio_api.MockEdgioClient))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config("off", "origin-1"),
+				ExpectError: regexp.MustCompile(`Origin 'origin-1' is not defined in origins`),
+			},
+			{
+				// The rules of every environment are checked against its own
+				// origins, whatever the rules_lint mode.
+				Config:      config("off", "origin-2"),
+				ExpectError: regexp.MustCompile(`/0/origin/set_origin: origin 'origin-1' is not defined`),
+			},
+			{
+				Config:      config("error", "origin-2"),
+				ExpectError: regexp.MustCompile(`origin "origin-1" is not defined in origins`),
+			},
+		},
+	})
+}
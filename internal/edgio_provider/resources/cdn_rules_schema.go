@@ -0,0 +1,194 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+// cdnRuleBlock returns the schema of the structured "rule" block, which is
+// compiled into the same JSON as the raw "rules" attribute.
+func cdnRuleBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Structured CDN rules, an alternative to the raw `rules` JSON. Each block is one entry of the rules array. " +
+			"The top-level `condition` and `features` form the `if` branch, a rule without conditions applies its features to every request.",
+		NestedObject: schema.NestedBlockObject{
+			Blocks: map[string]schema.Block{
+				"condition": cdnRuleConditionBlock(),
+				"features":  cdnRuleFeaturesBlock("Features applied when all conditions match."),
+				"elseif": schema.ListNestedBlock{
+					Description: "Branches evaluated in order when the previous conditions do not match.",
+					NestedObject: schema.NestedBlockObject{
+						Blocks: map[string]schema.Block{
+							"condition": cdnRuleConditionBlock(),
+							"features":  cdnRuleFeaturesBlock("Features applied when all conditions of this branch match."),
+						},
+					},
+				},
+				"else": cdnRuleFeaturesBlock("Features applied when no condition of the rule matches."),
+			},
+		},
+	}
+}
+
+func cdnRuleConditionBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Conditions of the branch. Multiple conditions are combined with `and`.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"variable": schema.StringAttribute{
+					Required: true,
+					Description: "The request variable to match. Possible values: `path`, `method`, `scheme`, `query_string`, `host`, " +
+						"`header`, `cookie`, `query`, `country`.",
+				},
+				"name": schema.StringAttribute{
+					Optional:    true,
+					Description: "The header, cookie or query parameter name, required for the `header`, `cookie` and `query` variables.",
+				},
+				"operator": schema.StringAttribute{
+					Optional: true,
+					Description: fmt.Sprintf("The comparison operator. Possible values: `%s`. Defaults to `==`.",
+						strings.Join(utility.RuleOperators, "`, `")),
+				},
+				"value": schema.StringAttribute{
+					Optional:    true,
+					Description: "The value to compare against.",
+				},
+				"values": schema.ListAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Description: "The values to compare against, used by the `in` and `not_in` operators.",
+				},
+			},
+		},
+	}
+}
+
+func cdnRuleFeaturesBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"edge_function": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the edge function that handles the request.",
+			},
+			"json": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional features as a JSON object, for features without a typed attribute. Typed attributes take precedence.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"caching": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"max_age": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Edge cache TTL by response status code, e.g. `{ \"200\" = \"1h\" }`.",
+					},
+					"client_max_age": schema.StringAttribute{
+						Optional: true,
+					},
+					"service_worker_max_age": schema.StringAttribute{
+						Optional: true,
+					},
+					"stale_while_revalidate": schema.StringAttribute{
+						Optional: true,
+					},
+					"bypass_cache": schema.BoolAttribute{
+						Optional: true,
+					},
+					"bypass_client_cache": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+			"headers": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"set_request_headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"set_response_headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"add_response_headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"remove_request_headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"remove_response_headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
+			"url": schema.SingleNestedBlock{
+				Blocks: map[string]schema.Block{
+					"url_rewrite": schema.ListNestedBlock{
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"source": schema.StringAttribute{
+									Optional: true,
+								},
+								"destination": schema.StringAttribute{
+									Optional: true,
+								},
+								"syntax": schema.StringAttribute{
+									Optional:    true,
+									Description: "Possible values: `path-to-regexp`, `regexp`.",
+								},
+							},
+						},
+					},
+					"url_redirect": schema.SingleNestedBlock{
+						Attributes: map[string]schema.Attribute{
+							"code": schema.Int64Attribute{
+								Optional: true,
+							},
+							"source": schema.StringAttribute{
+								Optional: true,
+							},
+							"destination": schema.StringAttribute{
+								Optional: true,
+							},
+							"syntax": schema.StringAttribute{
+								Optional:    true,
+								Description: "Possible values: `path-to-regexp`, `regexp`.",
+							},
+						},
+					},
+				},
+			},
+			"origin": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"set_origin": schema.StringAttribute{
+						Optional:    true,
+						Description: "Name of the origin that serves the request.",
+					},
+				},
+			},
+			"response": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"set_status_code": schema.Int64Attribute{
+						Optional: true,
+					},
+				},
+			},
+			"access": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"deny_access": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func deletionProtectionAttribute(resourceName string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: fmt.Sprintf("Whether the %s is protected from being destroyed or replaced. "+
+			"Set it to `false` and apply before destroying the %s. Defaults to `false`.", resourceName, resourceName),
+	}
+}
+
+// planDeletionProtection fails plans that destroy or replace a resource whose
+// prior state has deletion_protection enabled. The framework runs ModifyPlan
+// with an empty resp.RequiresReplace, so replacement is detected by comparing
+// the planned replaceAttributes, string attributes that replace the resource
+// when changed, with the prior state. Attribute plan modifiers that replace
+// the resource are not seen and must be listed in replaceAttributes as well.
+func planDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, resourceName string, replaceAttributes ...string) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		checkDeletionProtection(ctx, req.State, &resp.Diagnostics, resourceName, "destroyed")
+		return
+	}
+
+	for _, attribute := range replaceAttributes {
+		var prior, planned types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &prior)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !planned.Equal(prior) {
+			checkDeletionProtection(ctx, req.State, &resp.Diagnostics, resourceName, "replaced")
+			return
+		}
+	}
+}
+
+// checkDeletionProtection adds an error and returns true if deletion_protection
+// is enabled in state.
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics, resourceName, action string) bool {
+	var protected types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if !protected.ValueBool() {
+		return false
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s cannot be %s while deletion_protection is enabled. "+
+			"Set deletion_protection to false and apply that change first.", resourceName, action),
+	)
+	return true
+}
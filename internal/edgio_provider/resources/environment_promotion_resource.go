@@ -0,0 +1,280 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &EnvironmentPromotionResource{}
+	_ resource.ResourceWithValidateConfig = &EnvironmentPromotionResource{}
+	_ resource.ResourceWithModifyPlan     = &EnvironmentPromotionResource{}
+	_ resource.ResourceWithConfigure      = &EnvironmentPromotionResource{}
+)
+
+// EnvironmentPromotionResource uploads the configuration active on one
+// environment to another one.
+type EnvironmentPromotionResource struct {
+	client edgio_api.EdgioClientInterface
+}
+
+func NewEnvironmentPromotionResource() resource.Resource {
+	return &EnvironmentPromotionResource{}
+}
+
+func (r *EnvironmentPromotionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+	}
+}
+
+func (r *EnvironmentPromotionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "edgio_environment_promotion"
+}
+
+func (r *EnvironmentPromotionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Promotes the CDN configuration of a source environment to a target environment.",
+		Attributes: map[string]schema.Attribute{
+			"source_environment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The environment the configuration is promoted from.",
+			},
+			"source_configuration_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "The configuration to promote. Defaults to the configuration active on the source environment, " +
+					"which is looked up on every plan so that a newly uploaded source configuration is promoted as well. " +
+					"Set it to pin a configuration, it must belong to the source environment. Changing it promotes the new configuration.",
+			},
+			"target_environment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The environment the configuration is promoted to. Changing it forces a new promotion.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_configuration_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The configuration uploaded to the target environment.",
+			},
+			"hostname_mapping": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Replaces hostnames of the source configuration, keyed by the source hostname.",
+			},
+			"origin_hostname_mapping": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Replaces the hostnames of origin host locations of the source configuration, keyed by the source hostname.",
+			},
+			"origin_name_mapping": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Renames origins of the source configuration, keyed by the source origin name. References to renamed origins " +
+					"in `default_origin_name` of hostnames and `set_origin` of rules are renamed as well.",
+			},
+		},
+	}
+}
+
+func (r *EnvironmentPromotionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var source, target types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_environment_id"), &source)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("target_environment_id"), &target)...)
+	if resp.Diagnostics.HasError() || source.IsUnknown() || target.IsUnknown() {
+		return
+	}
+
+	if source.ValueString() == target.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_environment_id"),
+			"Invalid Target Environment",
+			"The target environment must differ from the source environment.",
+		)
+	}
+}
+
+// ModifyPlan looks up the configuration active on the source environment when
+// source_configuration_id is not set, so that uploading a new configuration to
+// the source environment shows up as a pending promotion.
+func (r *EnvironmentPromotionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creation and destruction have nothing to compare against, Create
+	// looks up the active configuration itself.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var configured, sourceEnvironment types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_configuration_id"), &configured)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_environment_id"), &sourceEnvironment)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() || sourceEnvironment.IsUnknown() {
+		return
+	}
+
+	active, err := r.client.GetEnvironmentCDNConfiguration(sourceEnvironment.ValueString())
+	if err != nil {
+		// Leave the configuration unknown, promote reports the error.
+		return
+	}
+
+	var current types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_configuration_id"), &current)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_configuration_id"), active.ConfigurationID)...)
+	if current.ValueString() != active.ConfigurationID {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("target_configuration_id"), types.StringUnknown())...)
+	}
+}
+
+func (r *EnvironmentPromotionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.EnvironmentPromotionModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.promote(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentPromotionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentPromotionModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.GetCDNConfiguration(state.TargetConfigurationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading CDN configuration", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentPromotionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.EnvironmentPromotionModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.promote(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// promote reads the source configuration, rewrites it through the mapping
+// tables and uploads it to the target environment. Without a planned source
+// configuration the one active on the source environment is promoted.
+func (r *EnvironmentPromotionResource) promote(plan *models.EnvironmentPromotionModel, diags *diag.Diagnostics) {
+	var source *dtos.CDNConfiguration
+	var err error
+	if plan.SourceConfigurationID.IsNull() || plan.SourceConfigurationID.IsUnknown() {
+		source, err = r.client.GetEnvironmentCDNConfiguration(plan.SourceEnvironmentID.ValueString())
+		if errors.Is(err, edgio_api.ErrNotFound) {
+			diags.AddAttributeError(
+				path.Root("source_environment_id"),
+				"No Source Configuration",
+				fmt.Sprintf("No configuration is active on the source environment %s.", plan.SourceEnvironmentID.ValueString()),
+			)
+			return
+		}
+	} else {
+		source, err = r.client.GetCDNConfiguration(plan.SourceConfigurationID.ValueString())
+	}
+	if err != nil {
+		diags.AddError("Error reading CDN configuration", err.Error())
+		return
+	}
+
+	if source.EnvironmentID != plan.SourceEnvironmentID.ValueString() {
+		diags.AddAttributeError(
+			path.Root("source_configuration_id"),
+			"Invalid Source Configuration",
+			fmt.Sprintf("Configuration %s belongs to environment %s, not to the source environment %s.",
+				source.ConfigurationID, source.EnvironmentID, plan.SourceEnvironmentID.ValueString()),
+		)
+		return
+	}
+
+	promoted, err := utility.PromoteCdnConfig(source, plan.TargetEnvironmentID.ValueString(), utility.PromotionMappings{
+		Hostnames:       utility.MapValueToStringMap(plan.HostnameMapping),
+		OriginHostnames: utility.MapValueToStringMap(plan.OriginHostnameMapping),
+		OriginNames:     utility.MapValueToStringMap(plan.OriginNameMapping),
+	})
+	if err != nil {
+		diags.AddError("Error promoting CDN configuration", err.Error())
+		return
+	}
+
+	cfg, err := r.client.UploadCdnConfiguration(&promoted)
+	if err != nil {
+		diags.AddError("Error creating CDN configuration", err.Error())
+		return
+	}
+
+	plan.SourceConfigurationID = types.StringValue(source.ConfigurationID)
+	plan.TargetConfigurationID = types.StringValue(cfg.ConfigurationID)
+}
+
+// Delete only removes the promotion from the state, the promoted configuration
+// stays active on the target environment.
+func (r *EnvironmentPromotionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.EnvironmentPromotionModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Promotion Not Undone",
+		fmt.Sprintf("Destroying edgio_environment_promotion does not undo the promotion, configuration %s stays active on environment %s.",
+			state.TargetConfigurationID.ValueString(), state.TargetEnvironmentID.ValueString()),
+	)
+}
@@ -0,0 +1,193 @@
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnvironmentPromotionResource(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	source := &dtos.CDNConfiguration{
+		ConfigurationID: "config-staging",
+		EnvironmentID:   "env-staging",
+		Rules:           json.RawMessage(`[{"origin":{"set_origin":"origin-1"}}]`),
+		Origins: []dtos.Origin{
+			{
+				Name: "origin-1",
+				Hosts: []dtos.Host{
+					{Location: utility.ToPtr([]dtos.Location{{Port: utility.ToPtr(int64(443)), Hostname: utility.ToPtr("staging.origin.example.com")}})},
+				},
+			},
+		},
+		Hostnames: []dtos.Hostname{
+			{Hostname: utility.ToPtr("staging.example.com"), DefaultOriginName: utility.ToPtr("origin-1")},
+		},
+	}
+	promoted := &dtos.CDNConfiguration{ConfigurationID: "config-production", EnvironmentID: "env-production"}
+
+	mockClient.On("GetCDNConfiguration", "config-staging").Return(source, nil)
+	mockClient.On("GetCDNConfiguration", "config-production").Return(promoted, nil)
+	mockClient.On("UploadCdnConfiguration", mock.MatchedBy(func(config *dtos.CDNConfiguration) bool {
+		return config.EnvironmentID == "env-production" &&
+			*config.Hostnames[0].Hostname == "www.example.com" &&
+			*(*config.Origins[0].Hosts[0].Location)[0].Hostname == "origin.example.com" &&
+			config.Origins[0].Name == "production" &&
+			*config.Hostnames[0].DefaultOriginName == "production" &&
+			string(config.Rules) == `[{"origin":{"set_origin":"production"}}]`
+	})).Return(promoted, nil)
+
+	config := func(originHostname string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment_promotion" "test" {
+			source_environment_id   = "env-staging"
+			source_configuration_id = "config-staging"
+			target_environment_id   = "env-production"
+
+			hostname_mapping = {
+				"staging.example.com" = "www.example.com"
+			}
+
+			origin_hostname_mapping = {
+				%q = "origin.example.com"
+			}
+
+			origin_name_mapping = {
+				"origin-1" = "production"
+			}
+		}`, originHostname)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("staging.origin.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_environment_promotion.test", "source_configuration_id", "config-staging"),
+					resource.TestCheckResourceAttr("edgio_environment_promotion.test", "target_configuration_id", "config-production"),
+				),
+			},
+			{
+				Config:      config("typo.origin.example.com"),
+				ExpectError: regexp.MustCompile(`hostnames \[typo\.origin\.example\.com\] are not`),
+			},
+		},
+	})
+
+	// The source configuration must not be rewritten in place.
+	if *source.Hostnames[0].Hostname != "staging.example.com" {
+		t.Errorf("source hostname was modified to %s", *source.Hostnames[0].Hostname)
+	}
+	if source.Origins[0].Name != "origin-1" || string(source.Rules) != `[{"origin":{"set_origin":"origin-1"}}]` {
+		t.Errorf("source origin was renamed: %s, %s", source.Origins[0].Name, source.Rules)
+	}
+}
+
+func TestEnvironmentPromotionResource_ActiveConfiguration(t *testing.T) {
+	fake := edgiofake.NewClient()
+	property, err := fake.CreateProperty(context.Background(), "org-123", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	environments := map[string]*dtos.Environment{}
+	for _, name := range []string{"staging", "production", "preview"} {
+		if environments[name], err = fake.CreateEnvironment(property.Id, name, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upload := func(originName string) string {
+		config, err := fake.UploadCdnConfiguration(&dtos.CDNConfiguration{
+			EnvironmentID: environments["staging"].Id,
+			Rules:         json.RawMessage(fmt.Sprintf(`[{"origin":{"set_origin":%q}}]`, originName)),
+			Origins:       []dtos.Origin{{Name: originName}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config.ConfigurationID
+	}
+	first := upload("origin-1")
+	var second string
+
+	config := func(target string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment_promotion" "test" {
+			source_environment_id = %q
+			target_environment_id = %q
+		}`, environments["staging"].Id, environments[target].Id)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(fake)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("production"),
+				Check:  resource.TestCheckResourceAttr("edgio_environment_promotion.test", "source_configuration_id", first),
+			},
+			{
+				// A new source configuration is promoted on the next apply.
+				PreConfig: func() { second = upload("origin-2") },
+				Config:    config("production"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment_promotion.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					attributes := s.RootModule().Resources["edgio_environment_promotion.test"].Primary.Attributes
+					if attributes["source_configuration_id"] != second {
+						return fmt.Errorf("expected source_configuration_id %s, got %s", second, attributes["source_configuration_id"])
+					}
+					promoted, err := fake.GetCDNConfiguration(attributes["target_configuration_id"])
+					if err != nil {
+						return err
+					}
+					if promoted.Origins[0].Name != "origin-2" {
+						return fmt.Errorf("expected origin-2 to be promoted, got %s", promoted.Origins[0].Name)
+					}
+					return nil
+				},
+			},
+			{
+				Config: config("preview"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment_promotion.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
@@ -10,19 +10,23 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ resource.Resource = &EnvironmentResource{}
+var (
+	_ resource.Resource               = &EnvironmentResource{}
+	_ resource.ResourceWithModifyPlan = &EnvironmentResource{}
+	_ resource.ResourceWithConfigure  = &EnvironmentResource{}
+)
 
 type EnvironmentResource struct {
-	client edgio_api.EdgioClientInterface
+	client            edgio_api.EdgioClientInterface
+	defaultPropertyID types.String
 }
 
-func NewEnvironmentResource(client edgio_api.EdgioClientInterface) *EnvironmentResource {
-	return &EnvironmentResource{
-		client: client,
-	}
+func NewEnvironmentResource() resource.Resource {
+	return &EnvironmentResource{}
 }
 
 func (r *EnvironmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -36,7 +40,10 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed: true,
 			},
 			"property_id": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Description: "The property of the environment, defaults to the `default_property_id` of the provider. " +
+					"Changing it replaces the environment, as it cannot be moved to another property.",
 			},
 			"legacy_account_number": schema.StringAttribute{
 				Computed: true,
@@ -54,6 +61,13 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
+			"preserve_cache": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether updates of the environment preserve its cache. Defaults to `false`, which purges the cache on every update.",
+			},
+			"deletion_protection": deletionProtectionAttribute("environment"),
 			"default_domain_name": schema.StringAttribute{
 				Computed: true,
 			},
@@ -74,8 +88,28 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+func (r *EnvironmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+		r.defaultPropertyID = data.DefaultPropertyID
+	}
+}
+
+func (r *EnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planProviderDefault(ctx, req, resp, "property_id", r.defaultPropertyID, "default_property_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planDeletionProtection(ctx, req, resp, "environment", "property_id")
+}
+
 func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan models.EnvironmentModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.EnvironmentResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -96,13 +130,21 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	newState := utility.ConvertEnvironmentToModel(env)
+	newState := models.EnvironmentResourceModel{
+		EnvironmentModel:   utility.ConvertEnvironmentToModel(env),
+		PreserveCache:      plan.PreserveCache,
+		DeletionProtection: plan.DeletionProtection,
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state models.EnvironmentModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -118,33 +160,59 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	newState := utility.ConvertEnvironmentToModel(env)
+	// preserve_cache and deletion_protection are not returned by the API.
+	newState := models.EnvironmentResourceModel{
+		EnvironmentModel:   utility.ConvertEnvironmentToModel(env),
+		PreserveCache:      state.PreserveCache,
+		DeletionProtection: state.DeletionProtection,
+	}
+	if newState.PreserveCache.IsNull() {
+		newState.PreserveCache = types.BoolValue(false)
+	}
+	if newState.DeletionProtection.IsNull() {
+		newState.DeletionProtection = types.BoolValue(false)
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state models.EnvironmentModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan models.EnvironmentModel
+	var plan models.EnvironmentResourceModel
 	diags = req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Changes of preserve_cache and deletion_protection alone do not call the
+	// API, an update would purge the cache.
+	if plan.Name.Equal(state.Name) &&
+		plan.OnlyMaintainersCanDeploy.Equal(state.OnlyMaintainersCanDeploy) &&
+		plan.HttpRequestLogging.Equal(state.HttpRequestLogging) {
+		state.PreserveCache = plan.PreserveCache
+		state.DeletionProtection = plan.DeletionProtection
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	updatedEnv, err := r.client.UpdateEnvironment(
 		state.Id.ValueString(),
 		plan.Name.ValueString(),
 		plan.OnlyMaintainersCanDeploy.ValueBool(),
 		plan.HttpRequestLogging.ValueBool(),
-		// TODO: What to do with this, it's not in the plan?
-		false)
+		plan.PreserveCache.ValueBool())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -154,19 +222,31 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	newState := utility.ConvertEnvironmentToModel(updatedEnv)
+	newState := models.EnvironmentResourceModel{
+		EnvironmentModel:   utility.ConvertEnvironmentToModel(updatedEnv),
+		PreserveCache:      plan.PreserveCache,
+		DeletionProtection: plan.DeletionProtection,
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state models.EnvironmentModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "environment", "destroyed") {
+		return
+	}
+
 	err := r.client.DeleteEnvironment(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
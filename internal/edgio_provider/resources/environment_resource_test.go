@@ -1,6 +1,8 @@
 package resources_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -107,3 +110,132 @@ func TestEnvironmentResource_Lifecycle(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestEnvironmentResource_PreserveCacheAndPropertyReplace(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	fixedTime := time.Date(2024, 10, 2, 10, 0, 0, 0, time.UTC)
+	environment := &dtos.Environment{
+		Id:         "env-123",
+		PropertyID: "property-123",
+		Name:       "example-environment",
+		CreatedAt:  fixedTime,
+		UpdatedAt:  fixedTime,
+	}
+	moved := &dtos.Environment{
+		Id:         "env-456",
+		PropertyID: "property-456",
+		Name:       "renamed-environment",
+		CreatedAt:  fixedTime,
+		UpdatedAt:  fixedTime,
+	}
+
+	mockClient.On("CreateEnvironment", "property-123", "example-environment", false, false).Return(environment, nil)
+	mockClient.On("GetEnvironment", "env-123").Return(environment, nil)
+	mockClient.On("UpdateEnvironment", "env-123", "renamed-environment", false, false, true).Run(func(args mock.Arguments) {
+		environment.Name = "renamed-environment"
+	}).Return(environment, nil)
+	mockClient.On("DeleteEnvironment", "env-123").Return(nil)
+	mockClient.On("CreateEnvironment", "property-456", "renamed-environment", false, false).Return(moved, nil)
+	mockClient.On("GetEnvironment", "env-456").Return(moved, nil)
+	mockClient.On("DeleteEnvironment", "env-456").Return(nil)
+
+	config := func(propertyID, name string, preserveCache bool) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment" "test" {
+			property_id    = %q
+			name           = %q
+			preserve_cache = %t
+		}`, propertyID, name, preserveCache)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("property-123", "example-environment", false),
+				Check:  resource.TestCheckResourceAttr("edgio_environment.test", "preserve_cache", "false"),
+			},
+			{
+				Config: config("property-123", "renamed-environment", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_environment.test", "name", "renamed-environment"),
+					resource.TestCheckResourceAttr("edgio_environment.test", "preserve_cache", "true"),
+				),
+			},
+			{
+				Config: config("property-456", "renamed-environment", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_environment.test", "id", "env-456"),
+					resource.TestCheckResourceAttr("edgio_environment.test", "property_id", "property-456"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestEnvironmentResource_DeletionProtectionBlocksReplace(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	mockAllEnvironmentMethods(mockClient, utility.MockCreate, utility.MockGet, utility.MockDelete)
+
+	config := func(propertyID string, deletionProtection bool) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment" "test" {
+			property_id          = %q
+			name                 = "example-environment"
+			http_request_logging = true
+			deletion_protection  = %t
+		}`, propertyID, deletionProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("property-123", true),
+				Check:  resource.TestCheckResourceAttr("edgio_environment.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      config("property-456", true),
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config:      config("property-123", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: config("property-123", false),
+			},
+		},
+	})
+
+	mockClient.AssertNumberOfCalls(t, "DeleteEnvironment", 1)
+}
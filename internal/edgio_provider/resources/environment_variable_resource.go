@@ -0,0 +1,270 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &EnvironmentVariableResource{}
+	_ resource.ResourceWithImportState    = &EnvironmentVariableResource{}
+	_ resource.ResourceWithValidateConfig = &EnvironmentVariableResource{}
+	_ resource.ResourceWithConfigure      = &EnvironmentVariableResource{}
+)
+
+type EnvironmentVariableResource struct {
+	client edgio_api.EdgioClientInterface
+}
+
+func NewEnvironmentVariableResource() resource.Resource {
+	return &EnvironmentVariableResource{}
+}
+
+func (r *EnvironmentVariableResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+	}
+}
+
+func (r *EnvironmentVariableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "edgio_environment_variable"
+}
+
+func (r *EnvironmentVariableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An environment variable used by edge functions and builds of an environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the variable. Changing it replaces the variable.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The value, stored in the state. Exactly one of `value` and `value_wo` must be set.",
+			},
+			"value_wo": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Description: "The value, never stored in the plan or the state. Requires Terraform 1.11 or later. " +
+					"Change `value_wo_version` to update the variable with a new value. Exactly one of `value` and `value_wo` must be set.",
+			},
+			"value_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Changing it updates the variable with the current `value_wo`.",
+			},
+			"secret": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the value is secret. The API does not return secret values, so changes made outside of Terraform are not detected.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *EnvironmentVariableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateExactlyOneOf(ctx, req.Config, &resp.Diagnostics, "value", "value_wo")
+
+	var valueWO types.String
+	var valueWOVersion types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_wo"), &valueWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_wo_version"), &valueWOVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if valueWO.IsNull() && !valueWOVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value_wo_version"),
+			"Invalid Attribute Combination",
+			"'value_wo_version' can only be set together with 'value_wo'.",
+		)
+	}
+}
+
+func (r *EnvironmentVariableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *EnvironmentVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.EnvironmentVariableModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value := variableValue(ctx, plan, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variable, err := r.client.CreateEnvironmentVariable(
+		plan.EnvironmentID.ValueString(),
+		plan.Key.ValueString(),
+		value,
+		plan.Secret.ValueBool(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Environment Variable",
+			fmt.Sprintf("Could not create environment variable %s, unexpected error: %s", plan.Key.ValueString(), err),
+		)
+		return
+	}
+
+	newState := utility.ConvertEnvironmentVariableToModel(variable, plan)
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentVariableModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variable, err := r.client.GetEnvironmentVariable(state.Id.ValueString())
+	if errors.Is(err, edgio_api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Variable",
+			fmt.Sprintf("Could not read environment variable ID %s, unexpected error: %s", state.Id.ValueString(), err),
+		)
+		return
+	}
+
+	newState := utility.ConvertEnvironmentVariableToModel(variable, state)
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentVariableModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan models.EnvironmentVariableModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value := variableValue(ctx, plan, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variable, err := r.client.UpdateEnvironmentVariable(
+		state.Id.ValueString(),
+		plan.Key.ValueString(),
+		value,
+		plan.Secret.ValueBool(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Environment Variable",
+			fmt.Sprintf("Could not update environment variable ID %s, unexpected error: %s", state.Id.ValueString(), err),
+		)
+		return
+	}
+
+	newState := utility.ConvertEnvironmentVariableToModel(variable, plan)
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.EnvironmentVariableModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEnvironmentVariable(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Environment Variable",
+			fmt.Sprintf("Could not delete environment variable ID %s, unexpected error: %s", state.Id.ValueString(), err),
+		)
+	}
+}
+
+// variableValue returns the planned value or, as write-only values are not
+// part of the plan, the configured value_wo.
+func variableValue(ctx context.Context, plan models.EnvironmentVariableModel, config tfsdk.Config, diags *diag.Diagnostics) string {
+	if !plan.Value.IsNull() {
+		return plan.Value.ValueString()
+	}
+
+	var valueWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("value_wo"), &valueWO)...)
+	return valueWO.ValueString()
+}
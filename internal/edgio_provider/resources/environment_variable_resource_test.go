@@ -0,0 +1,218 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnvironmentVariableResource_Lifecycle(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	fixedTime := time.Date(2024, 10, 2, 10, 0, 0, 0, time.UTC)
+	variable := &dtos.EnvironmentVariable{
+		Id:            "var-123",
+		EnvironmentID: "env-123",
+		Key:           "API_URL",
+		Value:         "https://api.example.com",
+		CreatedAt:     fixedTime,
+		UpdatedAt:     fixedTime,
+	}
+
+	mockClient.On("CreateEnvironmentVariable", "env-123", "API_URL", "https://api.example.com", false).Return(variable, nil)
+	mockClient.On("GetEnvironmentVariable", "var-123").Return(variable, nil)
+	mockClient.On("UpdateEnvironmentVariable", "var-123", "API_URL", "https://api2.example.com", false).Run(func(args mock.Arguments) {
+		variable.Value = "https://api2.example.com"
+		variable.UpdatedAt = fixedTime.Add(time.Hour)
+	}).Return(variable, nil)
+	mockClient.On("DeleteEnvironmentVariable", "var-123").Return(nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_environment_variable" "test" {
+					environment_id = "env-123"
+					key            = "API_URL"
+					value          = "https://api.example.com"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "id", "var-123"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "value", "https://api.example.com"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "secret", "false"),
+				),
+			},
+			{
+				ResourceName:      "edgio_environment_variable.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_environment_variable" "test" {
+					environment_id = "env-123"
+					key            = "API_URL"
+					value          = "https://api2.example.com"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "value", "https://api2.example.com"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "updated_at", "2024-10-02T11:00:00Z"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestEnvironmentVariableResource_WriteOnlySecret(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	fixedTime := time.Date(2024, 10, 2, 10, 0, 0, 0, time.UTC)
+	variable := &dtos.EnvironmentVariable{
+		Id:            "var-456",
+		EnvironmentID: "env-123",
+		Key:           "API_TOKEN",
+		Secret:        true,
+		CreatedAt:     fixedTime,
+		UpdatedAt:     fixedTime,
+	}
+
+	mockClient.On("CreateEnvironmentVariable", "env-123", "API_TOKEN", "token-1", true).Return(variable, nil)
+	mockClient.On("GetEnvironmentVariable", "var-456").Return(variable, nil)
+	mockClient.On("UpdateEnvironmentVariable", "var-456", "API_TOKEN", "token-2", true).Return(variable, nil)
+	mockClient.On("DeleteEnvironmentVariable", "var-456").Return(nil)
+
+	config := func(token string, version int) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment_variable" "test" {
+			environment_id   = "env-123"
+			key              = "API_TOKEN"
+			value_wo         = %q
+			value_wo_version = %d
+			secret           = true
+		}`, token, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("token-1", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("edgio_environment_variable.test", "value_wo"),
+					resource.TestCheckNoResourceAttr("edgio_environment_variable.test", "value"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "secret", "true"),
+				),
+			},
+			{
+				Config: config("token-2", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("edgio_environment_variable.test", "value_wo"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "value_wo_version", "2"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestEnvironmentVariableResource_DeletedAndRenamed(t *testing.T) {
+	fake := edgiofake.NewClient()
+	property, err := fake.CreateProperty(context.Background(), "org-123", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	environment, err := fake.CreateEnvironment(property.Id, "production", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(key string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_environment_variable" "test" {
+			environment_id = %q
+			key            = %q
+			value          = "https://api.example.com"
+		}`, environment.Id, key)
+	}
+
+	var variableID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(fake)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("API_URL"),
+				Check: func(s *terraform.State) error {
+					variableID = s.RootModule().Resources["edgio_environment_variable.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				// A variable deleted outside of Terraform is created again.
+				PreConfig: func() {
+					if err := fake.DeleteEnvironmentVariable(variableID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config("API_URL"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment_variable.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				Config: config("BACKEND_URL"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_environment_variable.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("edgio_environment_variable.test", "key", "BACKEND_URL"),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package resources_test
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func fakeAPIConfig(serverURL, slug, environmentName, value string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+	provider "edgio" {
+		client_id       = "fake-client-id"
+		client_secret   = "fake-client-secret"
+		api_url         = %[1]q
+		token_url       = "%[1]s/connect/token"
+		organization_id = "org-123"
+	}
+
+	resource "edgio_property" "test" {
+		slug          = %[2]q
+		force_destroy = %[5]t
+	}
+
+	resource "edgio_environment" "test" {
+		property_id = edgio_property.test.id
+		name        = %[3]q
+	}
+
+	resource "edgio_environment_variable" "test" {
+		environment_id = edgio_environment.test.id
+		key            = "API_URL"
+		value          = %[4]q
+	}`, serverURL, slug, environmentName, value, forceDestroy)
+}
+
+// TestFakeEdgioAPI_Lifecycle runs the provider with the real client against
+// the fake Edgio API server, without credentials or network access.
+func TestFakeEdgioAPI_Lifecycle(t *testing.T) {
+	fake := edgiofake.NewClient()
+	server := edgiofake.NewServer(fake)
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.New("test")()),
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			properties, err := fake.GetProperties(1, 100, "org-123")
+			if err != nil {
+				return err
+			}
+			if properties.TotalItems != 0 {
+				return fmt.Errorf("expected all properties to be deleted, got %d", properties.TotalItems)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fakeAPIConfig(server.URL, "example-slug", "production", "https://api.example.com", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_property.test", "organization_id", "org-123"),
+					resource.TestCheckResourceAttrPair("edgio_environment.test", "property_id", "edgio_property.test", "id"),
+					resource.TestCheckResourceAttr("edgio_environment.test", "default_domain_name", "example-slug-production.edgio.link"),
+					resource.TestCheckResourceAttrPair("edgio_environment_variable.test", "environment_id", "edgio_environment.test", "id"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "value", "https://api.example.com"),
+				),
+			},
+			{
+				ResourceName:      "edgio_environment_variable.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fakeAPIConfig(server.URL, "new-slug", "staging", "https://api2.example.com", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_property.test", "slug", "new-slug"),
+					resource.TestCheckResourceAttr("edgio_environment.test", "name", "staging"),
+					resource.TestCheckResourceAttr("edgio_environment_variable.test", "value", "https://api2.example.com"),
+				),
+			},
+			{
+				// An environment created outside of Terraform is deleted
+				// with the property.
+				PreConfig: func() {
+					properties, err := fake.GetProperties(1, 100, "org-123")
+					if err != nil || len(properties.Items) != 1 {
+						t.Fatalf("expected one property, got %v, %v", properties, err)
+					}
+					if _, err := fake.CreateEnvironment(properties.Items[0].Id, "manual", false, false); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fakeAPIConfig(server.URL, "new-slug", "staging", "https://api2.example.com", true),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "force_destroy", "true"),
+			},
+		},
+	})
+}
@@ -3,29 +3,38 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
 	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
 	"terraform-provider-edgio/internal/edgio_provider/models"
 	"terraform-provider-edgio/internal/edgio_provider/utility"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// listPageSize is the page size used when listing all environments or
+// certificates.
+const listPageSize = 100
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &PropertyResource{}
 	_ resource.ResourceWithImportState = &PropertyResource{}
+	_ resource.ResourceWithModifyPlan  = &PropertyResource{}
+	_ resource.ResourceWithConfigure   = &PropertyResource{}
 )
 
 type PropertyResource struct {
-	client edgio_api.EdgioClientInterface
+	client         edgio_api.EdgioClientInterface
+	organizationID types.String
 }
 
-func NewPropertyResource(client edgio_api.EdgioClientInterface) resource.Resource {
-	return &PropertyResource{
-		client: client,
-	}
+func NewPropertyResource() resource.Resource {
+	return &PropertyResource{}
 }
 
 func (r *PropertyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -36,7 +45,10 @@ func (r *PropertyResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Description: "The organization of the property, defaults to the `organization_id` of the provider. " +
+					"Changing it replaces the property, as it cannot be moved to another organization.",
 			},
 			"slug": schema.StringAttribute{
 				Required: true,
@@ -53,16 +65,63 @@ func (r *PropertyResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"updated_at": schema.StringAttribute{
 				Computed: true,
 			},
+			"deletion_protection": deletionProtectionAttribute("property"),
+			"force_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Whether destroying the property also deletes its environments and their TLS certificates, " +
+					"regardless of their `deletion_protection`. Otherwise the property cannot be destroyed while it has environments. " +
+					"Defaults to `false`.",
+			},
 		},
 	}
 }
 
+func (r *PropertyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+		r.organizationID = data.OrganizationID
+	}
+}
+
+func (r *PropertyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planProviderDefault(ctx, req, resp, "organization_id", r.organizationID, "organization_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planDeletionProtection(ctx, req, resp, "property", "organization_id")
+	if resp.Diagnostics.HasError() || len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	var state, plan models.PropertyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.OrganizationID.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("organization_id"),
+		"Property Will Be Replaced",
+		fmt.Sprintf("Properties cannot be moved between organizations. Changing organization_id from %s to %s "+
+			"destroys property %s and creates a new one with a new ID.",
+			state.OrganizationID.ValueString(), plan.OrganizationID.ValueString(), state.Id.ValueString()),
+	)
+}
+
 func (r *PropertyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 func (r *PropertyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan models.PropertyModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.PropertyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -78,13 +137,21 @@ func (r *PropertyResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	newState := utility.ConvertPropertyToModel(property)
+	newState := models.PropertyResourceModel{
+		PropertyModel:      utility.ConvertPropertyToModel(property),
+		DeletionProtection: plan.DeletionProtection,
+		ForceDestroy:       plan.ForceDestroy,
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *PropertyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state models.PropertyModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.PropertyResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -100,26 +167,52 @@ func (r *PropertyResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	newState := utility.ConvertPropertyToModel(property)
+	// deletion_protection and force_destroy are not returned by the API,
+	// imported properties get the defaults.
+	newState := models.PropertyResourceModel{
+		PropertyModel:      utility.ConvertPropertyToModel(property),
+		DeletionProtection: state.DeletionProtection,
+		ForceDestroy:       state.ForceDestroy,
+	}
+	if newState.DeletionProtection.IsNull() {
+		newState.DeletionProtection = types.BoolValue(false)
+	}
+	if newState.ForceDestroy.IsNull() {
+		newState.ForceDestroy = types.BoolValue(false)
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *PropertyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state models.PropertyModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.PropertyResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan models.PropertyModel
+	var plan models.PropertyResourceModel
 	diags = req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// A change of deletion_protection or force_destroy alone does not call the
+	// API, organization_id changes replace the property.
+	if plan.Slug.Equal(state.Slug) {
+		state.DeletionProtection = plan.DeletionProtection
+		state.ForceDestroy = plan.ForceDestroy
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	updatedProperty, err := r.client.UpdateProperty(ctx, state.Id.ValueString(), plan.Slug.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -129,20 +222,69 @@ func (r *PropertyResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	newState := utility.ConvertPropertyToModel(updatedProperty)
+	newState := models.PropertyResourceModel{
+		PropertyModel:      utility.ConvertPropertyToModel(updatedProperty),
+		DeletionProtection: plan.DeletionProtection,
+		ForceDestroy:       plan.ForceDestroy,
+	}
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *PropertyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state models.PropertyModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.PropertyResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.client.DeleteProperty(state.Id.ValueString())
+	if checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "property", "destroyed") {
+		return
+	}
+
+	environments, err := r.listEnvironments(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Property",
+			fmt.Sprintf("Could not list environments of property ID %s, unexpected error: %s", state.Id.ValueString(), err),
+		)
+		return
+	}
+
+	if len(environments) > 0 && !state.ForceDestroy.ValueBool() {
+		blocking := make([]string, 0, len(environments))
+		for _, environment := range environments {
+			blocking = append(blocking, fmt.Sprintf("%s (%s)", environment.Name, environment.Id))
+		}
+		resp.Diagnostics.AddError(
+			"Property Has Environments",
+			fmt.Sprintf("Property ID %s cannot be destroyed while it has environments: %s. "+
+				"Destroy them first, or set force_destroy to true and apply that change to delete them with the property.",
+				state.Id.ValueString(), strings.Join(blocking, ", ")),
+		)
+		return
+	}
+
+	// deletion_protection of the environments is only kept in the state of
+	// their edgio_environment resources, the API does not know about it, so
+	// force_destroy deletes protected environments as well.
+	for _, environment := range environments {
+		if err := r.deleteEnvironment(environment); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Property",
+				fmt.Sprintf("Could not delete environment %s (%s) of property ID %s, unexpected error: %s",
+					environment.Name, environment.Id, state.Id.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	err = r.client.DeleteProperty(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Property",
@@ -151,3 +293,44 @@ func (r *PropertyResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 }
+
+// listEnvironments returns all environments of the property.
+func (r *PropertyResource) listEnvironments(propertyID string) ([]dtos.Environment, error) {
+	var environments []dtos.Environment
+	for page := 1; ; page++ {
+		response, err := r.client.GetEnvironments(page, listPageSize, propertyID)
+		if err != nil {
+			return nil, err
+		}
+
+		environments = append(environments, response.Items...)
+		if len(response.Items) == 0 || len(environments) >= response.TotalItems {
+			return environments, nil
+		}
+	}
+}
+
+// deleteEnvironment deletes the TLS certificates of the environment and then
+// the environment itself.
+func (r *PropertyResource) deleteEnvironment(environment dtos.Environment) error {
+	var certificates []dtos.TLSCertResponse
+	for page := 1; ; page++ {
+		response, err := r.client.GetTlsCerts(page, listPageSize, environment.Id)
+		if err != nil {
+			return fmt.Errorf("failed to list TLS certificates: %w", err)
+		}
+
+		certificates = append(certificates, response.Certificates...)
+		if len(response.Certificates) == 0 || len(certificates) >= int(response.TotalItems) {
+			break
+		}
+	}
+
+	for _, certificate := range certificates {
+		if err := r.client.DeleteTlsCert(certificate.ID); err != nil {
+			return fmt.Errorf("failed to delete TLS certificate %s: %w", certificate.ID, err)
+		}
+	}
+
+	return r.client.DeleteEnvironment(environment.Id)
+}
@@ -1,6 +1,8 @@
 package resources_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -39,6 +42,7 @@ func mockAllPropertyMethods(mockClient *edgio_api.MockEdgioClient, methods ...ut
 				property.UpdatedAt = time.Now()
 			}).Return(property, nil)
 		case utility.MockDelete:
+			mockClient.On("GetEnvironments", 1, 100, "property-123").Return(&dtos.EnvironmentsResponse{}, nil)
 			mockClient.On("DeleteProperty", "property-123").Return(nil)
 		}
 	}
@@ -96,3 +100,248 @@ func TestPropertyResource_Lifecycle(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestPropertyResource_DeletionProtection(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	mockAllPropertyMethods(mockClient, utility.MockCreate, utility.MockGet, utility.MockDelete)
+
+	config := func(deletionProtection bool) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_property" "test" {
+			organization_id     = "org-123"
+			slug                = "example-slug"
+			deletion_protection = %t
+		}`, deletionProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      config(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "deletion_protection", "false"),
+			},
+		},
+	})
+
+	mockClient.AssertNumberOfCalls(t, "DeleteProperty", 1)
+}
+
+func TestPropertyResource_ForceDestroy(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	mockAllPropertyMethods(mockClient, utility.MockCreate, utility.MockGet)
+	mockClient.On("GetEnvironments", 1, 100, "property-123").Return(&dtos.EnvironmentsResponse{
+		TotalItems: 1,
+		Items:      []dtos.Environment{{Id: "env-123", Name: "production"}},
+	}, nil)
+	mockClient.On("GetTlsCerts", 1, 100, "env-123").Return(&dtos.TLSCertSResponse{
+		TotalItems:   1,
+		Certificates: []dtos.TLSCertResponse{{ID: "cert-123"}},
+	}, nil)
+	mockClient.On("DeleteTlsCert", "cert-123").Return(nil)
+	mockClient.On("DeleteEnvironment", "env-123").Return(nil)
+	mockClient.On("DeleteProperty", "property-123").Return(nil)
+
+	config := func(forceDestroy bool) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_property" "test" {
+			organization_id = "org-123"
+			slug            = "example-slug"
+			force_destroy   = %t
+		}`, forceDestroy)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "force_destroy", "false"),
+			},
+			{
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Property Has Environments`),
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "force_destroy", "true"),
+			},
+		},
+	})
+
+	mockClient.AssertNumberOfCalls(t, "DeleteTlsCert", 1)
+	mockClient.AssertNumberOfCalls(t, "DeleteEnvironment", 1)
+	mockClient.AssertNumberOfCalls(t, "DeleteProperty", 1)
+}
+
+func TestPropertyResource_OrganizationChange(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	fixedTime := time.Date(2024, 10, 2, 10, 0, 0, 0, time.UTC)
+	property := &dtos.Property{
+		OrganizationID: "org-123",
+		Slug:           "example-slug",
+		Id:             "property-123",
+		IdLink:         "property-link-123",
+		CreatedAt:      fixedTime,
+		UpdatedAt:      fixedTime,
+	}
+	movedProperty := &dtos.Property{
+		OrganizationID: "org-456",
+		Slug:           "new-slug",
+		Id:             "property-456",
+		IdLink:         "property-link-456",
+		CreatedAt:      fixedTime,
+		UpdatedAt:      fixedTime,
+	}
+
+	mockClient.On("CreateProperty", mock.Anything, "org-123", "example-slug").Return(property, nil)
+	mockClient.On("GetProperty", mock.Anything, "property-123").Return(property, nil)
+	mockClient.On("UpdateProperty", mock.Anything, "property-123", "new-slug").Run(func(args mock.Arguments) {
+		property.Slug = "new-slug"
+	}).Return(property, nil)
+	mockClient.On("GetEnvironments", 1, 100, mock.Anything).Return(&dtos.EnvironmentsResponse{}, nil)
+	mockClient.On("DeleteProperty", "property-123").Return(nil)
+	mockClient.On("CreateProperty", mock.Anything, "org-456", "new-slug").Return(movedProperty, nil)
+	mockClient.On("GetProperty", mock.Anything, "property-456").Return(movedProperty, nil)
+	mockClient.On("DeleteProperty", "property-456").Return(nil)
+
+	config := func(organizationID, slug string, deletionProtection bool) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_property" "test" {
+			organization_id     = %q
+			slug                = %q
+			deletion_protection = %t
+		}`, organizationID, slug, deletionProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("org-123", "example-slug", true),
+				Check:  resource.TestCheckResourceAttr("edgio_property.test", "id", "property-123"),
+			},
+			{
+				Config: config("org-123", "new-slug", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_property.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("edgio_property.test", "slug", "new-slug"),
+			},
+			{
+				Config:      config("org-456", "new-slug", true),
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: config("org-123", "new-slug", false),
+			},
+			{
+				Config: config("org-456", "new-slug", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_property.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_property.test", "id", "property-456"),
+					resource.TestCheckResourceAttr("edgio_property.test", "organization_id", "org-456"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertCalled(t, "DeleteProperty", "property-123")
+}
+
+func TestPropertyResource_ProviderOrganization(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	mockAllPropertyMethods(mockClient, utility.MockCreate, utility.MockGet, utility.MockDelete)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "edgio" {
+					client_id       = "mock-client-id"
+					client_secret   = "mock-client-secret"
+					organization_id = "org-123"
+				}
+
+				resource "edgio_property" "test" {
+					slug = "example-slug"
+				}`,
+				Check: resource.TestCheckResourceAttr("edgio_property.test", "organization_id", "org-123"),
+			},
+			{
+				Config: `
+				provider "edgio" {
+					client_id       = "mock-client-id"
+					client_secret   = "mock-client-secret"
+					organization_id = "org-456"
+				}
+
+				resource "edgio_property" "test" {
+					organization_id = "org-123"
+					slug            = "example-slug"
+				}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_property" "test" {
+					slug = "example-slug"
+				}`,
+				ExpectError: regexp.MustCompile(`Missing Attribute`),
+			},
+		},
+	})
+}
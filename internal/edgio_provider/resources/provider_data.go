@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// planProviderDefault plans an attribute that falls back to the provider
+// attribute providerAttribute when it is not configured. Changing the resolved
+// value replaces the resource, which stringplanmodifier.RequiresReplace cannot
+// detect as it does not see provider defaults.
+func planProviderDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string, providerDefault types.String, providerAttribute string) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var value types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if value.IsNull() {
+		if providerDefault.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing Attribute",
+				fmt.Sprintf("'%s' must be set either on the resource or as '%s' on the provider.", attribute, providerAttribute),
+			)
+			return
+		}
+
+		value = providerDefault
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), value)...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &prior)...)
+	if !value.Equal(prior) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root(attribute))
+	}
+}
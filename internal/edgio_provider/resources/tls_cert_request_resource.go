@@ -0,0 +1,374 @@
+package resources
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &TLSCertRequestResource{}
+	_ resource.ResourceWithConfigure  = &TLSCertRequestResource{}
+	_ resource.ResourceWithModifyPlan = &TLSCertRequestResource{}
+)
+
+// TLSCertRequestResource generates a private key and CSR locally and uploads the
+// certificate signed by an external CA together with that key.
+type TLSCertRequestResource struct {
+	client edgio_api.EdgioClientInterface
+}
+
+func NewTLSCertRequestResource() resource.Resource {
+	return &TLSCertRequestResource{}
+}
+
+func (r *TLSCertRequestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
+	}
+}
+
+func (r *TLSCertRequestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "edgio_tls_cert_request"
+}
+
+func (r *TLSCertRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the generated public key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "An environment's system-defined ID (e.g., 12345678-1234-1234-1234-1234567890ab).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_algorithm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(utility.KeyAlgorithmRSA),
+				Description: "Algorithm of the generated private key. Possible values: `RSA`, `ECDSA`. Defaults to `RSA`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rsa_bits": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(2048),
+				Description: "Size of the generated RSA key in bits. Only used when `key_algorithm` is `RSA`. Defaults to `2048`.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"ecdsa_curve": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("P256"),
+				Description: "Curve of the generated ECDSA key. Only used when `key_algorithm` is `ECDSA`. Possible values: `P224`, `P256`, `P384`, `P521`. Defaults to `P256`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"common_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The common name (CN) of the certificate request.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				Optional:    true,
+				Description: "The organization (O) of the certificate request.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dns_names": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The Subject Alternative Names (SAN) of the certificate request.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_version": schema.Int64Attribute{
+				Optional: true,
+				Description: "Any number, changing it generates a new private key and CSR. Increment it and remove `signed_cert` " +
+					"to rotate the key, then sign the new `cert_request_pem` and set `signed_cert` again.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				Description: "The generated private key in PKCS #8 PEM format. It is kept in the Terraform state, which must be " +
+					"protected accordingly. Use `edgio_tls_cert` with `private_key_wo` to keep private keys out of the state.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cert_request_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "The generated certificate signing request (CSR) in PEM format. Pass it to your CA to obtain `signed_cert`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"signed_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "The certificate issued by your CA for `cert_request_pem`. Once set, it is uploaded together with the generated private key.",
+			},
+			"intermediate_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "The intermediate certificates (IC) used by the CA, including the CA’s signing certificate.",
+			},
+			"tls_cert_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The system-defined ID of the uploaded TLS certificate.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The uploaded TLS certificate's status. Possible values: `created`, `activating`, `activated`, `failed`, `expired`.",
+			},
+			"expiration": schema.StringAttribute{
+				Computed:    true,
+				Description: "The uploaded TLS certificate's expiration date and time (UTC).",
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects a signed_cert when a new key is generated, on creation and
+// on replacement, as it cannot have been issued for a CSR that does not exist
+// yet. Catching it here keeps a replacement from deleting the previous upload.
+func (r *TLSCertRequestResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var signedCert types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("signed_cert"), &signedCert)...)
+	if signedCert.IsNull() || signedCert.IsUnknown() || signedCert.ValueString() == "" {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("signed_cert"),
+		"Signed Certificate Set For A New Key",
+		"This plan generates a new private key and CSR, so 'signed_cert' cannot have been issued for them. "+
+			"Remove 'signed_cert', apply, sign the new 'cert_request_pem' and set 'signed_cert' again.",
+	)
+}
+
+func (r *TLSCertRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.TLSCertRequestModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := utility.GeneratePrivateKey(plan.KeyAlgorithm.ValueString(), int(plan.RSABits.ValueInt64()), plan.ECDSACurve.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Generating Private Key", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	keyPEM, err := utility.EncodePrivateKeyPEM(key)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Generating Private Key", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	csrPEM, err := utility.CreateCertificateRequestPEM(
+		key,
+		plan.CommonName.ValueString(),
+		plan.Organization.ValueString(),
+		utility.TypesListToStringSlice(plan.DNSNames))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Certificate Request", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	fingerprint, err := utility.PublicKeyFingerprint(key)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Certificate Request", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	plan.ID = types.StringValue(fingerprint)
+	plan.PrivateKeyPEM = types.StringValue(keyPEM)
+	plan.CertRequestPEM = types.StringValue(csrPEM)
+
+	resp.Diagnostics.Append(r.uploadSignedCert(&plan, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *TLSCertRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.TLSCertRequestModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Until a signed certificate is provided there is nothing stored remotely.
+	if state.TLSCertID.IsNull() || state.TLSCertID.ValueString() == "" {
+		return
+	}
+
+	tlsCertResponse, err := r.client.GetTlsCert(state.TLSCertID.ValueString())
+	if errors.Is(err, edgio_api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error Fetching TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	state.Status = types.StringValue(tlsCertResponse.Status)
+	state.Expiration = types.StringValue(tlsCertResponse.Expiration)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *TLSCertRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state models.TLSCertRequestModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Key and CSR changes force a replacement, so the key from state is still the
+	// one the signed certificate must match.
+	key, err := utility.DecodePrivateKeyPEM(plan.PrivateKeyPEM.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Private Key", fmt.Sprintf("Error: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.uploadSignedCert(&plan, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Uploaded certificates cannot be changed, so the previous upload is
+	// superseded by the new one.
+	if previousID := state.TLSCertID.ValueString(); previousID != "" && previousID != plan.TLSCertID.ValueString() {
+		if err := r.client.DeleteTlsCert(previousID); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Deleting Previous TLS Cert",
+				fmt.Sprintf("The previously uploaded TLS certificate %s could not be deleted and must be removed manually: %s", previousID, err.Error()),
+			)
+		}
+	}
+}
+
+func (r *TLSCertRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.TLSCertRequestModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.TLSCertID.IsNull() || state.TLSCertID.ValueString() == "" {
+		return
+	}
+
+	if err := r.client.DeleteTlsCert(state.TLSCertID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error Deleting TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
+	}
+}
+
+// uploadSignedCert uploads plan.SignedCert with the generated key and fills in
+// the upload attributes. Without a signed certificate they are cleared.
+func (r *TLSCertRequestResource) uploadSignedCert(plan *models.TLSCertRequestModel, key crypto.Signer) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.SignedCert.IsNull() || plan.SignedCert.ValueString() == "" {
+		plan.TLSCertID = types.StringNull()
+		plan.Status = types.StringNull()
+		plan.Expiration = types.StringNull()
+		return diags
+	}
+
+	if err := utility.CertificateMatchesKey(plan.SignedCert.ValueString(), key); err != nil {
+		diags.AddError(
+			"Invalid Signed Certificate",
+			fmt.Sprintf("'signed_cert' must be issued for 'cert_request_pem': %s", err.Error()),
+		)
+		return diags
+	}
+
+	res, err := r.client.UploadTlsCert(dtos.UploadTlsCertRequest{
+		EnvironmentID:    plan.EnvironmentID.ValueString(),
+		PrimaryCert:      plan.SignedCert.ValueString(),
+		IntermediateCert: plan.IntermediateCert.ValueString(),
+		PrivateKey:       plan.PrivateKeyPEM.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Error Uploading TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
+		return diags
+	}
+
+	plan.TLSCertID = types.StringValue(res.ID)
+	plan.Status = types.StringValue(res.Status)
+	plan.Expiration = types.StringValue(res.Expiration)
+	return diags
+}
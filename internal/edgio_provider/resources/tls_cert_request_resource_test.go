@@ -0,0 +1,325 @@
+package resources_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"terraform-provider-edgio/internal/edgio_api"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_api/edgiofake"
+	"terraform-provider-edgio/internal/edgio_provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/mock"
+)
+
+// signCertRequest acts as the internal CA: it signs the CSR stored in state and
+// writes the certificate to certPath so the next step can read it with file().
+func signCertRequest(resourceName, certPath string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		block, _ := pem.Decode([]byte(rs.Primary.Attributes["cert_request_pem"]))
+		if block == nil {
+			return fmt.Errorf("cert_request_pem is not PEM encoded")
+		}
+
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			return err
+		}
+
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(42),
+			Subject:      csr.Subject,
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+		}
+		caTemplate := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "Test CA"},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, csr.PublicKey, caKey)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	}
+}
+
+func TestTLSCertRequestResource_Lifecycle(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+	certPath := filepath.Join(t.TempDir(), "signed.pem")
+
+	uploadedCert := &dtos.TLSCertResponse{
+		ID:            "cert-789",
+		EnvironmentID: "env-123",
+		Expiration:    "2024-10-03T10:00:00Z",
+		Status:        "activated",
+		CommonName:    "example.com",
+	}
+
+	mockClient.On("UploadTlsCert", mock.MatchedBy(func(req dtos.UploadTlsCertRequest) bool {
+		return req.EnvironmentID == "env-123" && req.PrimaryCert != "" && req.PrivateKey != ""
+	})).Return(uploadedCert, nil).Once()
+	mockClient.On("GetTlsCert", "cert-789").Return(uploadedCert, nil)
+	mockClient.On("DeleteTlsCert", "cert-789").Return(nil).Once()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			// Generate the key and CSR, nothing is uploaded yet
+			{
+				Config: `
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_tls_cert_request" "test" {
+					environment_id = "env-123"
+					key_algorithm  = "ECDSA"
+					common_name    = "example.com"
+					dns_names      = ["example.com", "www.example.com"]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("edgio_tls_cert_request.test", "id"),
+					resource.TestCheckResourceAttrSet("edgio_tls_cert_request.test", "private_key_pem"),
+					resource.TestCheckResourceAttrSet("edgio_tls_cert_request.test", "cert_request_pem"),
+					resource.TestCheckNoResourceAttr("edgio_tls_cert_request.test", "tls_cert_id"),
+					signCertRequest("edgio_tls_cert_request.test", certPath),
+				),
+			},
+			// Upload the certificate signed by the CA
+			{
+				Config: fmt.Sprintf(`
+				provider "edgio" {
+					client_id     = "mock-client-id"
+					client_secret = "mock-client-secret"
+				}
+
+				resource "edgio_tls_cert_request" "test" {
+					environment_id = "env-123"
+					key_algorithm  = "ECDSA"
+					common_name    = "example.com"
+					dns_names      = ["example.com", "www.example.com"]
+					signed_cert    = file(%q)
+				}`, certPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("edgio_tls_cert_request.test", "tls_cert_id", "cert-789"),
+					resource.TestCheckResourceAttr("edgio_tls_cert_request.test", "status", "activated"),
+				),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestTLSCertRequestResource_Rotation(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+	certPath := filepath.Join(t.TempDir(), "signed.pem")
+
+	uploadedCert := &dtos.TLSCertResponse{ID: "cert-789", EnvironmentID: "env-123", Status: "activated"}
+	reuploadedCert := &dtos.TLSCertResponse{ID: "cert-790", EnvironmentID: "env-123", Status: "activated"}
+
+	mockClient.On("UploadTlsCert", mock.MatchedBy(func(req dtos.UploadTlsCertRequest) bool {
+		return req.IntermediateCert == ""
+	})).Return(uploadedCert, nil).Once()
+	mockClient.On("UploadTlsCert", mock.MatchedBy(func(req dtos.UploadTlsCertRequest) bool {
+		return req.IntermediateCert != ""
+	})).Return(reuploadedCert, nil).Once()
+	mockClient.On("GetTlsCert", "cert-789").Return(uploadedCert, nil)
+	mockClient.On("GetTlsCert", "cert-790").Return(reuploadedCert, nil)
+	mockClient.On("DeleteTlsCert", "cert-789").Return(nil).Once()
+	mockClient.On("DeleteTlsCert", "cert-790").Return(nil).Once()
+
+	config := func(rotationVersion int, attributes string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_tls_cert_request" "test" {
+			environment_id   = "env-123"
+			key_algorithm    = "ECDSA"
+			common_name      = "example.com"
+			rotation_version = %d
+			%s
+		}`, rotationVersion, attributes)
+	}
+	signedCert := fmt.Sprintf("signed_cert = file(%q)", certPath)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(1, ""),
+				Check:  signCertRequest("edgio_tls_cert_request.test", certPath),
+			},
+			{
+				Config: config(1, signedCert),
+				Check:  resource.TestCheckResourceAttr("edgio_tls_cert_request.test", "tls_cert_id", "cert-789"),
+			},
+			// Changing the intermediate certificate uploads the certificate again
+			// and deletes the previous upload
+			{
+				Config: config(1, signedCert+"\nintermediate_cert = \"intermediate\""),
+				Check:  resource.TestCheckResourceAttr("edgio_tls_cert_request.test", "tls_cert_id", "cert-790"),
+			},
+			// Rotating generates a new key, so the certificate of the previous
+			// key is rejected before anything is deleted
+			{
+				Config:      config(2, signedCert+"\nintermediate_cert = \"intermediate\""),
+				ExpectError: regexp.MustCompile(`Signed Certificate Set For A New Key`),
+			},
+			{
+				Config: config(2, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_tls_cert_request.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckNoResourceAttr("edgio_tls_cert_request.test", "tls_cert_id"),
+			},
+		},
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestTLSCertRequestResource_EnvironmentChange(t *testing.T) {
+	mockClient := new(edgio_api.MockEdgioClient)
+
+	config := func(environmentID string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_tls_cert_request" "test" {
+			environment_id = %q
+			key_algorithm  = "ECDSA"
+			common_name    = "example.com"
+		}`, environmentID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(mockClient)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("env-123"),
+			},
+			{
+				Config: config("env-456"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_tls_cert_request.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TestTLSCertRequestResource_DeletedCert checks that a certificate deleted
+// outside of Terraform removes the resource from state.
+func TestTLSCertRequestResource_DeletedCert(t *testing.T) {
+	fake := edgiofake.NewClient()
+	property, err := fake.CreateProperty(context.Background(), "org-123", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	environment, err := fake.CreateEnvironment(property.Id, "production", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := filepath.Join(t.TempDir(), "signed.pem")
+	config := func(attributes string) string {
+		return fmt.Sprintf(`
+		provider "edgio" {
+			client_id     = "mock-client-id"
+			client_secret = "mock-client-secret"
+		}
+
+		resource "edgio_tls_cert_request" "test" {
+			environment_id = %q
+			key_algorithm  = "ECDSA"
+			common_name    = "example.com"
+			%s
+		}`, environment.Id, attributes)
+	}
+
+	var tlsCertID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.NewMockedProvider(fake)),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check:  signCertRequest("edgio_tls_cert_request.test", certPath),
+			},
+			{
+				Config: config(fmt.Sprintf("signed_cert = file(%q)", certPath)),
+				Check: func(s *terraform.State) error {
+					tlsCertID = s.RootModule().Resources["edgio_tls_cert_request.test"].Primary.Attributes["tls_cert_id"]
+					if tlsCertID == "" {
+						return fmt.Errorf("expected tls_cert_id to be set")
+					}
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					if err := fake.DeleteTlsCert(tlsCertID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("edgio_tls_cert_request.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
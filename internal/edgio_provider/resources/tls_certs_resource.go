@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,16 +18,25 @@ import (
 	"terraform-provider-edgio/internal/edgio_provider/utility"
 )
 
-// Ensure the implementation satisfies the resource.Resource interface.
-var _ resource.Resource = &TLSCertsResource{}
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &TLSCertsResource{}
+	_ resource.ResourceWithValidateConfig = &TLSCertsResource{}
+	_ resource.ResourceWithModifyPlan     = &TLSCertsResource{}
+	_ resource.ResourceWithConfigure      = &TLSCertsResource{}
+)
 
 type TLSCertsResource struct {
 	client edgio_api.EdgioClientInterface
 }
 
-func NewTLSCertsResource(client edgio_api.EdgioClientInterface) *TLSCertsResource {
-	return &TLSCertsResource{
-		client: client,
+func NewTLSCertsResource() resource.Resource {
+	return &TLSCertsResource{}
+}
+
+func (r *TLSCertsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := models.ProviderDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.Client
 	}
 }
 
@@ -51,13 +62,24 @@ func (r *TLSCertsResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Computed:    true,
 			},
 			"private_key": schema.StringAttribute{
-				Optional:    true,
-				Description: "The private key that was generated with the CSR.",
-				Sensitive:   true,
+				Optional:           true,
+				Description:        "The private key that was generated with the CSR. The key is stored in the Terraform state, use `private_key_wo` instead.",
+				Sensitive:          true,
+				DeprecationMessage: "The private key is stored in the Terraform state. Use private_key_wo instead.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"private_key_wo": schema.StringAttribute{
+				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
+				Description: "The private key that was generated with the CSR. The value is never stored in the Terraform state and may come from an ephemeral resource. Requires Terraform 1.11 or later.",
+			},
+			"private_key_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the configured private key, used to detect key changes without storing the key.",
+			},
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The resource's system-defined ID.",
@@ -103,59 +125,94 @@ func (r *TLSCertsResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
-func (r *TLSCertsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan models.TLSCertModel
-	diags := req.Plan.Get(ctx, &plan)
+func (r *TLSCertsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.TLSCertResourceModel
+	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	generate := plan.PrimaryCert.ValueString() == "" && plan.IntermediateCert.ValueString() == "" && plan.PrivateKey.ValueString() == ""
-
-	if !generate && (plan.PrimaryCert.ValueString() == "" && plan.IntermediateCert.ValueString() == "" && plan.PrivateKey.ValueString() == "") {
-		resp.Diagnostics.AddError(
-			"Invalid Certificate Input",
-			"If you provide one of 'primary_cert', 'intermediate_cert', or 'private_key', you must provide all three.",
+	if !config.PrivateKey.IsNull() && !config.PrivateKeyWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_key_wo"),
+			"Conflicting Private Key Attributes",
+			"Only one of 'private_key' and 'private_key_wo' can be set.",
 		)
+	}
+}
+
+// ModifyPlan plans the fingerprint of the configured private key. Write-only
+// values never reach the plan, so the fingerprint is what makes a key change
+// show up as an update.
+func (r *TLSCertsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config models.TLSCertResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tlsRes := dtos.TLSCertResponse{}
+	key := config.PrivateKeyWO
+	if key.IsNull() {
+		key = config.PrivateKey
+	}
 
-	if generate {
-		res, err := r.client.GenerateTlsCert(plan.EnvironmentID.ValueString())
+	fingerprint := types.StringNull()
+	if key.IsUnknown() {
+		fingerprint = types.StringUnknown()
+	} else if key.ValueString() != "" {
+		fingerprint = types.StringValue(utility.SecretFingerprint(key.ValueString()))
+	}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Error Generating TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
-			return
-		}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("private_key_sha256"), fingerprint)...)
+}
 
-		tlsRes = *res
-	} else {
-		res, err := r.client.UploadTlsCert(dtos.UploadTlsCertRequest{
-			EnvironmentID:    plan.EnvironmentID.ValueString(),
-			PrimaryCert:      plan.PrimaryCert.ValueString(),
-			IntermediateCert: plan.IntermediateCert.ValueString(),
-			PrivateKey:       plan.PrivateKey.ValueString(),
-		})
+func (r *TLSCertsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Error Uploading TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
-			return
-		}
+	var plan models.TLSCertResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		tlsRes = *res
+	var config models.TLSCertResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	newState := utility.ConvertTlsCertsToModel(&tlsRes)
+	tlsRes, diags := r.createOrUpload(&plan, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState := models.TLSCertResourceModel{
+		TLSCertModel:     utility.ConvertTlsCertsToModel(tlsRes),
+		PrivateKeyWO:     types.StringNull(),
+		PrivateKeySHA256: plan.PrivateKeySHA256,
+	}
 	newState.PrivateKey = plan.PrivateKey
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *TLSCertsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state models.TLSCertModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state models.TLSCertResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -168,61 +225,96 @@ func (r *TLSCertsResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	newState := utility.ConvertTlsCertsToModel(tlsCertResponse)
+	newState := models.TLSCertResourceModel{
+		TLSCertModel:     utility.ConvertTlsCertsToModel(tlsCertResponse),
+		PrivateKeyWO:     types.StringNull(),
+		PrivateKeySHA256: state.PrivateKeySHA256,
+	}
 	newState.PrivateKey = state.PrivateKey
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *TLSCertsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan models.TLSCertModel
+	if !models.RequireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan models.TLSCertResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	generate := plan.PrimaryCert.ValueString() == "" && plan.IntermediateCert.ValueString() == "" && plan.PrivateKey.ValueString() == ""
+	var config models.TLSCertResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if !generate && (plan.PrimaryCert.ValueString() == "" || plan.IntermediateCert.ValueString() == "" || plan.PrivateKey.ValueString() == "") {
-		resp.Diagnostics.AddError(
-			"Invalid Certificate Input",
-			"If you provide one of 'primary_cert', 'intermediate_cert', or 'private_key', you must provide all three.",
-		)
+	tlsRes, diags := r.createOrUpload(&plan, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tlsRes := dtos.TLSCertResponse{}
+	newState := models.TLSCertResourceModel{
+		TLSCertModel:     utility.ConvertTlsCertsToModel(tlsRes),
+		PrivateKeyWO:     types.StringNull(),
+		PrivateKeySHA256: plan.PrivateKeySHA256,
+	}
+	newState.PrivateKey = plan.PrivateKey
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+// createOrUpload generates a certificate when no certificate data is configured
+// and uploads the configured certificate otherwise. The private key is taken
+// from the configuration because write-only values are not part of the plan.
+func (r *TLSCertsResource) createOrUpload(plan, config *models.TLSCertResourceModel) (*dtos.TLSCertResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	privateKey := config.PrivateKeyWO.ValueString()
+	if privateKey == "" {
+		privateKey = plan.PrivateKey.ValueString()
+	}
+
+	generate := plan.PrimaryCert.ValueString() == "" && plan.IntermediateCert.ValueString() == "" && privateKey == ""
+
+	if !generate && (plan.PrimaryCert.ValueString() == "" || plan.IntermediateCert.ValueString() == "" || privateKey == "") {
+		diags.AddError(
+			"Invalid Certificate Input",
+			"If you provide one of 'primary_cert', 'intermediate_cert', or 'private_key' ('private_key_wo'), you must provide all three.",
+		)
+		return nil, diags
+	}
 
 	if generate {
 		res, err := r.client.GenerateTlsCert(plan.EnvironmentID.ValueString())
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error Generating TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
-			return
+			diags.AddError("Error Generating TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
+			return nil, diags
 		}
 
-		tlsRes = *res
-	} else {
-		res, err := r.client.UploadTlsCert(dtos.UploadTlsCertRequest{
-			EnvironmentID:    plan.EnvironmentID.ValueString(),
-			PrimaryCert:      plan.PrimaryCert.ValueString(),
-			IntermediateCert: plan.IntermediateCert.ValueString(),
-			PrivateKey:       plan.PrivateKey.ValueString(),
-		})
+		return res, diags
+	}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Error Uploading TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
-			return
-		}
+	res, err := r.client.UploadTlsCert(dtos.UploadTlsCertRequest{
+		EnvironmentID:    plan.EnvironmentID.ValueString(),
+		PrimaryCert:      plan.PrimaryCert.ValueString(),
+		IntermediateCert: plan.IntermediateCert.ValueString(),
+		PrivateKey:       privateKey,
+	})
 
-		tlsRes = *res
+	if err != nil {
+		diags.AddError("Error Uploading TLS Cert", fmt.Sprintf("Error: %s", err.Error()))
+		return nil, diags
 	}
 
-	newState := utility.ConvertTlsCertsToModel(&tlsRes)
-	newState.PrivateKey = plan.PrivateKey
-	diags = resp.State.Set(ctx, &newState)
-	resp.Diagnostics.Append(diags...)
+	return res, diags
 }
 
 func (r *TLSCertsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
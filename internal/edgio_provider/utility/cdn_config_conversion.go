@@ -1,26 +1,33 @@
 package utility
 
 import (
+	"context"
 	"encoding/json"
+	"maps"
+	"slices"
 	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
 	"terraform-provider-edgio/internal/edgio_provider/models"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-func ConvertCdnConfigToNative(model *models.CDNConfigurationModel) dtos.CDNConfiguration {
+func ConvertCdnConfigToNative(model *models.CDNConfigurationModel) (dtos.CDNConfiguration, diag.Diagnostics) {
 	rules := json.RawMessage(model.Rules.ValueString())
+	hostnames, diags := convertHostnamesToNative(hostnamesOf(model))
 
 	return dtos.CDNConfiguration{
 		ConfigurationID:        model.ConfigurationID.ValueString(),
 		Rules:                  rules,
 		EnvironmentID:          model.EnvironmentID.ValueString(),
-		Origins:                convertOriginsToNative(model.Origins),
-		Hostnames:              convertHostnamesToNative(model.Hostnames),
+		Origins:                convertOriginsToNative(originsOf(model)),
+		Hostnames:              hostnames,
 		Experiments:            TypesListToStringSlicePointer(model.Experiments),
 		EdgeFunctionsSources:   MapValueToStringMapPointer(model.EdgeFunctionsSources),
 		EdgeFunctionInitScript: ToPtrString(model.EdgeFunctionInitScript),
-	}
+	}, diags
 }
 
 func ConvertNativeToCdnConfig(dto *dtos.CDNConfiguration) models.CDNConfigurationModel {
@@ -39,12 +46,144 @@ func ConvertNativeToCdnConfig(dto *dtos.CDNConfiguration) models.CDNConfiguratio
 		ConfigurationID:        types.StringValue(dto.ConfigurationID),
 		EnvironmentID:          types.StringValue(dto.EnvironmentID),
 		Origins:                convertNativeToOrigins(dto.Origins),
-		Rules:                  types.StringValue(rulesStr),
+		Rules:                  customtypes.NewRulesJSONValue(rulesStr),
 		Hostnames:              convertNativeToHostnames(dto.Hostnames),
 		Experiments:            StringSliceToTypesList(dto.Experiments),
 		EdgeFunctionsSources:   StringMapToMapValue(dto.EdgeFunctionsSources),
 		EdgeFunctionInitScript: types.StringPointerValue(dto.EdgeFunctionInitScript),
+		EdgeFunctionsHash:      types.StringValue(edgeFunctionsHashOf(dto)),
+	}
+}
+
+func edgeFunctionsHashOf(dto *dtos.CDNConfiguration) string {
+	var sources map[string]string
+	if dto.EdgeFunctionsSources != nil {
+		sources = *dto.EdgeFunctionsSources
 	}
+
+	var initScript string
+	if dto.EdgeFunctionInitScript != nil {
+		initScript = *dto.EdgeFunctionInitScript
+	}
+
+	return EdgeFunctionsHash(sources, initScript)
+}
+
+// ConvertCdnConfigV0 upgrades a state from before origins and hostnames could
+// be keyed by name. The upgraded state keeps using the list forms and the rules
+// JSON, which is brought into its canonical form.
+func ConvertCdnConfigV0(prior *models.CDNConfigurationModelV0) models.CDNConfigurationModel {
+	rules := customtypes.RulesJSON{StringValue: prior.Rules}
+	if canonical, err := MinifyJSON(prior.Rules.ValueString()); err == nil && !prior.Rules.IsNull() {
+		rules = customtypes.NewRulesJSONValue(canonical)
+	}
+
+	return models.CDNConfigurationModel{
+		ConfigurationID:        prior.ConfigurationID,
+		EnvironmentID:          prior.EnvironmentID,
+		Rules:                  rules,
+		Origins:                prior.Origins,
+		Hostnames:              prior.Hostnames,
+		Experiments:            prior.Experiments,
+		EdgeFunctionsSources:   prior.EdgeFunctionsSources,
+		EdgeFunctionInitScript: prior.EdgeFunctionInitScript,
+		EdgeFunctionsHash:      types.StringValue(EdgeFunctionsHash(MapValueToStringMap(prior.EdgeFunctionsSources), prior.EdgeFunctionInitScript.ValueString())),
+		RulesLint:              types.StringNull(),
+	}
+}
+
+// ArrangeCdnConfigLike puts the origins and hostnames returned by the API into
+// the form used by reference, typically the plan or the prior state: keyed by
+// name if reference uses the keyed form, otherwise as a list in the order of
+// reference, so that a different API order does not show up as a diff.
+// Entries unknown to the reference keep their API order at the end.
+func ArrangeCdnConfigLike(model *models.CDNConfigurationModel, reference *models.CDNConfigurationModel) {
+	if reference.OriginsByName != nil {
+		model.OriginsByName = keyedBy(model.Origins, originName)
+		model.Origins = nil
+	} else {
+		model.Origins = orderedLike(model.Origins, reference.Origins, originName)
+	}
+
+	if reference.HostnamesByName != nil {
+		model.HostnamesByName = keyedBy(model.Hostnames, hostnameName)
+		model.Hostnames = nil
+	} else {
+		model.Hostnames = orderedLike(model.Hostnames, reference.Hostnames, hostnameName)
+	}
+}
+
+// originsOf returns the origins of whichever form is set, keyed origins are
+// ordered by name.
+func originsOf(model *models.CDNConfigurationModel) []models.OriginModel {
+	if model.OriginsByName == nil {
+		return model.Origins
+	}
+
+	var origins []models.OriginModel
+	for _, name := range slices.Sorted(maps.Keys(model.OriginsByName)) {
+		origin := model.OriginsByName[name]
+		origin.Name = types.StringValue(name)
+		origins = append(origins, origin)
+	}
+	return origins
+}
+
+// hostnamesOf returns the hostnames of whichever form is set, keyed hostnames
+// are ordered by hostname.
+func hostnamesOf(model *models.CDNConfigurationModel) []models.HostnameModel {
+	if model.HostnamesByName == nil {
+		return model.Hostnames
+	}
+
+	var hostnames []models.HostnameModel
+	for _, name := range slices.Sorted(maps.Keys(model.HostnamesByName)) {
+		hostname := model.HostnamesByName[name]
+		hostname.Hostname = types.StringValue(name)
+		hostnames = append(hostnames, hostname)
+	}
+	return hostnames
+}
+
+func originName(origin models.OriginModel) string {
+	return origin.Name.ValueString()
+}
+
+func hostnameName(hostname models.HostnameModel) string {
+	return hostname.Hostname.ValueString()
+}
+
+func keyedBy[T any](items []T, key func(T) string) map[string]T {
+	keyed := make(map[string]T, len(items))
+	for _, item := range items {
+		keyed[key(item)] = item
+	}
+	return keyed
+}
+
+func orderedLike[T any](items []T, reference []T, key func(T) string) []T {
+	position := make(map[string]int, len(reference))
+	for i, item := range reference {
+		position[key(item)] = i
+	}
+
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b T) int {
+		positionA, knownA := position[key(a)]
+		positionB, knownB := position[key(b)]
+		switch {
+		case knownA && knownB:
+			return positionA - positionB
+		case knownA:
+			return -1
+		case knownB:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return ordered
 }
 
 func convertOriginsToNative(origins []models.OriginModel) []dtos.Origin {
@@ -72,10 +211,10 @@ func convertNativeToOrigins(origins []dtos.Origin) []models.OriginModel {
 			Name:                types.StringValue(origin.Name),
 			Type:                types.StringPointerValue(origin.Type),
 			Hosts:               convertNativeToHosts(origin.Hosts),
-			Balancer:            types.StringPointerValue(origin.Balancer),
+			Balancer:            types.StringPointerValue(withDefault(origin.Balancer, DefaultOriginBalancer)),
 			OverrideHostHeader:  types.StringPointerValue(origin.OverrideHostHeader),
 			Shields:             convertNativeToShields(origin.Shields),
-			PciCertifiedShields: types.BoolPointerValue(origin.PciCertifiedShields),
+			PciCertifiedShields: types.BoolPointerValue(withDefault(origin.PciCertifiedShields, DefaultOriginPciCertifiedShields)),
 			TLSVerify:           convertNativeToTLSVerify(origin.TLSVerify),
 			Retry:               convertNativeToRetry(origin.Retry),
 		})
@@ -83,18 +222,21 @@ func convertNativeToOrigins(origins []dtos.Origin) []models.OriginModel {
 	return m
 }
 
-func convertHostnamesToNative(hostnames []models.HostnameModel) []dtos.Hostname {
+func convertHostnamesToNative(hostnames []models.HostnameModel) ([]dtos.Hostname, diag.Diagnostics) {
 	var natives []dtos.Hostname
+	var diags diag.Diagnostics
 	for _, hostname := range hostnames {
+		tls, d := convertTLSToNative(hostname.TLS)
+		diags.Append(d...)
 		natives = append(natives, dtos.Hostname{
 			Hostname:          ToPtrString(hostname.Hostname),
 			DefaultOriginName: ToPtrString(hostname.DefaultOriginName),
 			ReportCode:        ToPtrInt64(hostname.ReportCode),
-			TLS:               convertTLSToNative(hostname.TLS),
+			TLS:               tls,
 			Directory:         ToPtrString(hostname.Directory),
 		})
 	}
-	return natives
+	return natives, diags
 }
 
 func convertNativeToHostnames(hostnames []dtos.Hostname) []models.HostnameModel {
@@ -111,9 +253,17 @@ func convertNativeToHostnames(hostnames []dtos.Hostname) []models.HostnameModel
 	return natives
 }
 
-func convertTLSToNative(tls *models.TLSModel) *dtos.TLS {
-	if tls == nil {
-		return nil
+// convertTLSToNative omits TLS settings that are not known yet, so that the API
+// fills in its defaults.
+func convertTLSToNative(object types.Object) (*dtos.TLS, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	var tls models.TLSModel
+	diags := object.As(context.Background(), &tls, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	return &dtos.TLS{
@@ -131,15 +281,15 @@ func convertTLSToNative(tls *models.TLSModel) *dtos.TLS {
 		OCSP:                ToPtrBool(tls.OCSP),
 		PEM:                 ToPtrString(tls.PEM),
 		CA:                  ToPtrString(tls.CA),
-	}
+	}, diags
 }
 
-func convertNativeToTLS(tls *dtos.TLS) *models.TLSModel {
+func convertNativeToTLS(tls *dtos.TLS) types.Object {
 	if tls == nil {
-		return nil
+		return types.ObjectNull(models.TLSAttributeTypes)
 	}
 
-	return &models.TLSModel{
+	object, _ := types.ObjectValueFrom(context.Background(), models.TLSAttributeTypes, models.TLSModel{
 		NPN:                 types.BoolPointerValue(tls.NPN),
 		ALPN:                types.BoolPointerValue(tls.ALPN),
 		Protocols:           types.StringPointerValue(tls.Protocols),
@@ -154,7 +304,9 @@ func convertNativeToTLS(tls *dtos.TLS) *models.TLSModel {
 		OCSP:                types.BoolPointerValue(tls.OCSP),
 		PEM:                 types.StringPointerValue(tls.PEM),
 		CA:                  types.StringPointerValue(tls.CA),
-	}
+	})
+
+	return object
 }
 
 func convertRetryToNative(retry *models.RetryModel) *dtos.Retry {
@@ -230,9 +382,9 @@ func convertNativeToTLSVerify(tlsVerify *dtos.TLSVerify) *models.TLSVerifyModel
 	}
 
 	return &models.TLSVerifyModel{
-		UseSNI:                   types.BoolPointerValue(tlsVerify.UseSNI),
+		UseSNI:                   types.BoolPointerValue(withDefault(tlsVerify.UseSNI, DefaultTLSVerifyUseSNI)),
 		SNIHintAndStrictSanCheck: types.StringPointerValue(tlsVerify.SNIHintAndStrictSanCheck),
-		AllowSelfSignedCerts:     types.BoolPointerValue(tlsVerify.AllowSelfSignedCerts),
+		AllowSelfSignedCerts:     types.BoolPointerValue(withDefault(tlsVerify.AllowSelfSignedCerts, DefaultTLSVerifyAllowSelfSignedCerts)),
 		PinnedCerts:              StringSliceToTypesList(tlsVerify.PinnedCerts),
 	}
 }
@@ -262,18 +414,18 @@ func convertNativeToHosts(hosts []dtos.Host) []models.HostModel {
 	var m []models.HostModel
 	for _, host := range hosts {
 		m = append(m, models.HostModel{
-			Weight:                   types.Int64PointerValue(host.Weight),
-			DNSMaxTTL:                types.Int64PointerValue(host.DNSMaxTTL),
-			DNSPreference:            types.StringPointerValue(host.DNSPreference),
-			MaxHardPool:              types.Int64PointerValue(host.MaxHardPool),
-			DNSMinTTL:                types.Int64PointerValue(host.DNSMinTTL),
+			Weight:                   types.Int64PointerValue(withDefault(host.Weight, DefaultHostWeight)),
+			DNSMaxTTL:                types.Int64PointerValue(withDefault(host.DNSMaxTTL, DefaultHostDNSMaxTTL)),
+			DNSPreference:            types.StringPointerValue(withDefault(host.DNSPreference, DefaultHostDNSPreference)),
+			MaxHardPool:              types.Int64PointerValue(withDefault(host.MaxHardPool, DefaultHostMaxHardPool)),
+			DNSMinTTL:                types.Int64PointerValue(withDefault(host.DNSMinTTL, DefaultHostDNSMinTTL)),
 			Location:                 convertNativeToLocation(host.Location),
-			MaxPool:                  types.Int64PointerValue(host.MaxPool),
+			MaxPool:                  types.Int64PointerValue(withDefault(host.MaxPool, DefaultHostMaxPool)),
 			Balancer:                 types.StringPointerValue(host.Balancer),
 			Scheme:                   types.StringPointerValue(host.Scheme),
 			OverrideHostHeader:       types.StringPointerValue(host.OverrideHostHeader),
 			SNIHintAndStrictSanCheck: types.StringPointerValue(host.SNIHintAndStrictSanCheck),
-			UseSNI:                   types.BoolPointerValue(host.UseSNI),
+			UseSNI:                   types.BoolPointerValue(withDefault(host.UseSNI, DefaultHostUseSNI)),
 		})
 	}
 	return m
@@ -0,0 +1,49 @@
+package utility_test
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configWithTLS returns a configuration with one hostname using the TLS settings.
+func configWithTLS(tls types.Object) *models.CDNConfigurationModel {
+	return &models.CDNConfigurationModel{
+		Hostnames:            []models.HostnameModel{{Hostname: types.StringValue("www.example.com"), TLS: tls}},
+		Experiments:          types.ListNull(types.StringType),
+		EdgeFunctionsSources: types.MapNull(types.StringType),
+	}
+}
+
+func TestConvertCdnConfigToNative_TLS(t *testing.T) {
+	tls, diags := types.ObjectValueFrom(context.Background(), models.TLSAttributeTypes, models.TLSModel{
+		SNI:       types.BoolValue(true),
+		Protocols: types.StringValue("TLSv1.2"),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	native, diags := utility.ConvertCdnConfigToNative(configWithTLS(tls))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if native.Hostnames[0].TLS == nil || !*native.Hostnames[0].TLS.SNI || *native.Hostnames[0].TLS.Protocols != "TLSv1.2" {
+		t.Errorf("unexpected TLS settings: %+v", native.Hostnames[0].TLS)
+	}
+
+	// Settings that do not match the TLS model are reported, not dropped.
+	invalid := types.ObjectValueMust(
+		map[string]attr.Type{"sni": types.StringType},
+		map[string]attr.Value{"sni": types.StringValue("yes")},
+	)
+	_, diags = utility.ConvertCdnConfigToNative(configWithTLS(invalid))
+	if !diags.HasError() {
+		t.Error("expected an error for invalid TLS settings")
+	}
+}
@@ -0,0 +1,30 @@
+package utility
+
+// Defaults the Edgio API applies to origins and hosts that leave them unset. The
+// schema plans them as defaults so that unset attributes are known at plan time,
+// and they are filled in when the API omits them from a response, so that they
+// do not show up as drift. Attributes without a documented default keep their
+// prior state instead.
+const (
+	DefaultOriginBalancer            = "round_robin"
+	DefaultOriginPciCertifiedShields = false
+
+	DefaultHostWeight        = 200
+	DefaultHostDNSMinTTL     = 600
+	DefaultHostDNSMaxTTL     = 3600
+	DefaultHostDNSPreference = "prefv4"
+	DefaultHostMaxHardPool   = 10
+	DefaultHostMaxPool       = 0
+	DefaultHostUseSNI        = false
+
+	DefaultTLSVerifyUseSNI               = false
+	DefaultTLSVerifyAllowSelfSignedCerts = false
+)
+
+// withDefault returns value, or def if the API omitted the value.
+func withDefault[T any](value *T, def T) *T {
+	if value == nil {
+		return &def
+	}
+	return value
+}
@@ -0,0 +1,141 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+// PromotionMappings are the mapping tables applied to a promoted configuration,
+// each keyed by the source value.
+type PromotionMappings struct {
+	Hostnames       map[string]string
+	OriginHostnames map[string]string
+	OriginNames     map[string]string
+}
+
+// PromoteCdnConfig copies the source configuration for upload to the target
+// environment. Hostnames, origin host locations and origin names found in the
+// mapping tables are replaced by their mapped value. Renamed origins are also
+// renamed where hostnames and rules refer to them, otherwise the rules are
+// copied unchanged. Every mapping entry must match at least once, so that a typo
+// does not silently promote the source value.
+func PromoteCdnConfig(source *dtos.CDNConfiguration, targetEnvironmentID string, mappings PromotionMappings) (dtos.CDNConfiguration, error) {
+	usedHostnames := map[string]bool{}
+	usedOriginHostnames := map[string]bool{}
+	usedOriginNames := map[string]bool{}
+	// References to origins do not count as a match of the origin name.
+	referencedOriginNames := map[string]bool{}
+
+	hostnames := make([]dtos.Hostname, 0, len(source.Hostnames))
+	for _, hostname := range source.Hostnames {
+		hostname.Hostname = mapped(hostname.Hostname, mappings.Hostnames, usedHostnames)
+		hostname.DefaultOriginName = mapped(hostname.DefaultOriginName, mappings.OriginNames, referencedOriginNames)
+		hostnames = append(hostnames, hostname)
+	}
+
+	origins := make([]dtos.Origin, 0, len(source.Origins))
+	for _, origin := range source.Origins {
+		hosts := make([]dtos.Host, 0, len(origin.Hosts))
+		for _, host := range origin.Hosts {
+			if host.Location != nil {
+				locations := make([]dtos.Location, 0, len(*host.Location))
+				for _, location := range *host.Location {
+					location.Hostname = mapped(location.Hostname, mappings.OriginHostnames, usedOriginHostnames)
+					locations = append(locations, location)
+				}
+				host.Location = &locations
+			}
+			hosts = append(hosts, host)
+		}
+		origin.Hosts = hosts
+		origin.Name = *mapped(&origin.Name, mappings.OriginNames, usedOriginNames)
+		origins = append(origins, origin)
+	}
+
+	rules := slices.Clone(source.Rules)
+	if len(mappings.OriginNames) > 0 && len(source.Rules) > 0 {
+		var err error
+		rules, err = renameRuleOrigins(source.Rules, mappings.OriginNames)
+		if err != nil {
+			return dtos.CDNConfiguration{}, fmt.Errorf("configuration %s: %w", source.ConfigurationID, err)
+		}
+	}
+
+	if unused := unusedKeys(mappings.Hostnames, usedHostnames); len(unused) > 0 {
+		return dtos.CDNConfiguration{}, fmt.Errorf("hostname_mapping: hostnames %v are not used by configuration %s", unused, source.ConfigurationID)
+	}
+
+	if unused := unusedKeys(mappings.OriginHostnames, usedOriginHostnames); len(unused) > 0 {
+		return dtos.CDNConfiguration{}, fmt.Errorf("origin_hostname_mapping: origin hostnames %v are not used by configuration %s", unused, source.ConfigurationID)
+	}
+
+	if unused := unusedKeys(mappings.OriginNames, usedOriginNames); len(unused) > 0 {
+		return dtos.CDNConfiguration{}, fmt.Errorf("origin_name_mapping: origins %v are not defined by configuration %s", unused, source.ConfigurationID)
+	}
+
+	return dtos.CDNConfiguration{
+		EnvironmentID:          targetEnvironmentID,
+		Rules:                  rules,
+		Origins:                origins,
+		Hostnames:              hostnames,
+		Experiments:            source.Experiments,
+		EdgeFunctionsSources:   source.EdgeFunctionsSources,
+		EdgeFunctionInitScript: source.EdgeFunctionInitScript,
+	}, nil
+}
+
+func mapped(value *string, mapping map[string]string, used map[string]bool) *string {
+	if value == nil {
+		return nil
+	}
+
+	target, ok := mapping[*value]
+	if !ok {
+		return value
+	}
+
+	used[*value] = true
+	return &target
+}
+
+// renameRuleOrigins replaces the set_origin values of the rules found in
+// mapping.
+func renameRuleOrigins(rules json.RawMessage, mapping map[string]string) (json.RawMessage, error) {
+	document, err := customtypes.DecodeJSON(string(rules))
+	if err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	list, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rules must be a JSON array")
+	}
+
+	for i, rule := range list {
+		forEachRuleFeatures(asObject(rule), fmt.Sprintf("/%d", i), func(features map[string]interface{}, _ string) {
+			origin := asObject(features["origin"])
+			if name, ok := origin["set_origin"].(string); ok {
+				if target, ok := mapping[name]; ok {
+					origin["set_origin"] = target
+				}
+			}
+		})
+	}
+
+	return json.Marshal(list)
+}
+
+func unusedKeys(mapping map[string]string, used map[string]bool) []string {
+	var unused []string
+	for key := range mapping {
+		if !used[key] {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
@@ -0,0 +1,67 @@
+package utility
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+// builtInOriginPrefix marks the origins Edgio provides itself, such as
+// edgio_serverless and edgio_static, which never appear in origins.
+const builtInOriginPrefix = "edgio_"
+
+// hostnamePattern accepts RFC 1123 host names with an optional leading
+// wildcard label.
+var hostnamePattern = regexp.MustCompile(`(?i)^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// IsBuiltInOrigin reports whether the origin is provided by Edgio rather than
+// defined in the origins of a CDN configuration.
+func IsBuiltInOrigin(name string) bool {
+	return strings.HasPrefix(name, builtInOriginPrefix)
+}
+
+// ValidateHostname checks that hostname is a syntactically valid host name.
+func ValidateHostname(hostname string) error {
+	if len(hostname) > 253 {
+		return fmt.Errorf("hostname %q is longer than 253 characters", hostname)
+	}
+
+	if !hostnamePattern.MatchString(hostname) {
+		return fmt.Errorf("hostname %q is not a valid host name", hostname)
+	}
+
+	return nil
+}
+
+// RuleOriginReference is a set_origin feature of the rules JSON.
+type RuleOriginReference struct {
+	Pointer string
+	Origin  string
+}
+
+// RuleOriginReferences lists the origins the rules send requests to, together
+// with the JSON pointer of each set_origin.
+func RuleOriginReferences(rules string) ([]RuleOriginReference, error) {
+	document, err := customtypes.DecodeJSON(rules)
+	if err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	list, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rules must be a JSON array")
+	}
+
+	var references []RuleOriginReference
+	for i, rule := range list {
+		forEachRuleFeatures(asObject(rule), fmt.Sprintf("/%d", i), func(features map[string]interface{}, pointer string) {
+			if origin, ok := asObject(features["origin"])["set_origin"].(string); ok {
+				references = append(references, RuleOriginReference{Pointer: pointer + "/origin/set_origin", Origin: origin})
+			}
+		})
+	}
+
+	return references, nil
+}
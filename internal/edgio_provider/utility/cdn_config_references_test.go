@@ -0,0 +1,34 @@
+package utility_test
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+func TestRuleOriginReferences(t *testing.T) {
+	rules := `[
+		{"origin": {"set_origin": "web"}},
+		{"if": [{"==": [{"request": "path"}, "/api"]}, {"origin": {"set_origin": "api"}}, {"else": {"origin": {"set_origin": "edgio_static"}}}]},
+		{"caching": {"max_age": {"200": "1h"}}}
+	]`
+
+	references, err := utility.RuleOriginReferences(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []utility.RuleOriginReference{
+		{Pointer: "/0/origin/set_origin", Origin: "web"},
+		{Pointer: "/1/if/1/origin/set_origin", Origin: "api"},
+		{Pointer: "/1/if/2/else/origin/set_origin", Origin: "edgio_static"},
+	}
+	if !reflect.DeepEqual(references, expected) {
+		t.Errorf("expected %v, got %v", expected, references)
+	}
+
+	if _, err := utility.RuleOriginReferences(`{"origin": {}}`); err == nil {
+		t.Error("expected an error for rules that are not an array")
+	}
+}
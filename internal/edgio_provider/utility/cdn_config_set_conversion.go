@@ -0,0 +1,103 @@
+package utility
+
+import (
+	"reflect"
+	"slices"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+)
+
+// CdnConfigForEnvironment returns the configuration of the i-th environment of
+// the set, the base configuration with the environment's overrides applied.
+// It is uploaded with ConvertCdnConfigToNative like edgio_cdn_configuration.
+func CdnConfigForEnvironment(set *models.CDNConfigurationSetModel, i int) models.CDNConfigurationModel {
+	environment := set.Environments[i]
+
+	config := models.CDNConfigurationModel{
+		EnvironmentID:          environment.EnvironmentID,
+		Rules:                  set.Rules,
+		Origins:                set.Origins,
+		Hostnames:              set.Hostnames,
+		Experiments:            set.Experiments,
+		EdgeFunctionsSources:   set.EdgeFunctionsSources,
+		EdgeFunctionInitScript: set.EdgeFunctionInitScript,
+	}
+
+	if environment.Origins != nil {
+		config.Origins = environment.Origins
+	}
+	if environment.Hostnames != nil {
+		config.Hostnames = environment.Hostnames
+	}
+	if !environment.Experiments.IsNull() {
+		config.Experiments = environment.Experiments
+	}
+
+	return config
+}
+
+// StoreCdnConfigInSet puts the configuration returned by the API for the i-th
+// environment back into the set. Overridden values are stored in the
+// environment. Base values are shared by several environments, so they are only
+// taken from the first environment using them. The response of every other
+// environment is compared with the base instead, and the names of the base
+// attributes it differs from are returned.
+func StoreCdnConfigInSet(set *models.CDNConfigurationSetModel, i int, config models.CDNConfigurationModel) []string {
+	reference := CdnConfigForEnvironment(set, i)
+	ArrangeCdnConfigLike(&config, &reference)
+
+	environment := &set.Environments[i]
+	var differences []string
+
+	switch {
+	case environment.Origins != nil:
+		environment.Origins = config.Origins
+	case isFirstUsingBase(set, i, func(e models.CDNConfigurationEnvironmentModel) bool { return e.Origins == nil }):
+		set.Origins = config.Origins
+	case !reflect.DeepEqual(set.Origins, config.Origins):
+		differences = append(differences, "origins")
+	}
+
+	switch {
+	case environment.Hostnames != nil:
+		environment.Hostnames = config.Hostnames
+	case isFirstUsingBase(set, i, func(e models.CDNConfigurationEnvironmentModel) bool { return e.Hostnames == nil }):
+		set.Hostnames = config.Hostnames
+	case !reflect.DeepEqual(set.Hostnames, config.Hostnames):
+		differences = append(differences, "hostnames")
+	}
+
+	switch {
+	case !environment.Experiments.IsNull():
+	case isFirstUsingBase(set, i, func(e models.CDNConfigurationEnvironmentModel) bool { return e.Experiments.IsNull() }):
+		set.Experiments = config.Experiments
+	case !set.Experiments.Equal(config.Experiments):
+		differences = append(differences, "experiments")
+	}
+
+	// Rules and edge functions cannot be overridden, every environment uses
+	// the base values.
+	if i == 0 {
+		set.Rules = config.Rules
+		set.EdgeFunctionsSources = config.EdgeFunctionsSources
+		set.EdgeFunctionInitScript = config.EdgeFunctionInitScript
+		return differences
+	}
+
+	if !set.Rules.StringValue.Equal(config.Rules.StringValue) {
+		differences = append(differences, "rules")
+	}
+	if !set.EdgeFunctionsSources.Equal(config.EdgeFunctionsSources) {
+		differences = append(differences, "edge_functions_sources")
+	}
+	if !set.EdgeFunctionInitScript.Equal(config.EdgeFunctionInitScript) {
+		differences = append(differences, "edge_function_init_script")
+	}
+
+	return differences
+}
+
+// isFirstUsingBase reports whether the i-th environment is the first one of the
+// set for which usesBase is true.
+func isFirstUsingBase(set *models.CDNConfigurationSetModel, i int, usesBase func(models.CDNConfigurationEnvironmentModel) bool) bool {
+	return slices.IndexFunc(set.Environments, usesBase) == i
+}
@@ -0,0 +1,291 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ruleVariables maps the condition variables of the structured rule model to
+// the variable objects used by the rules JSON. Variables marked as named take
+// the header, cookie or query parameter name from the condition's name.
+var ruleVariables = map[string]struct {
+	key   string
+	value string
+	named bool
+}{
+	"path":         {key: "request", value: "path"},
+	"method":       {key: "request", value: "method"},
+	"scheme":       {key: "request", value: "scheme"},
+	"query_string": {key: "request", value: "query"},
+	"host":         {key: "request.header", value: "host"},
+	"header":       {key: "request.header", named: true},
+	"cookie":       {key: "request.cookie", named: true},
+	"query":        {key: "request.querystring", named: true},
+	"country":      {key: "location", value: "country"},
+}
+
+// RuleOperators lists the condition operators supported by the structured rule
+// model. "in" and "not_in" compare against a list of values.
+var RuleOperators = []string{"==", "!=", "=~", "!~", "in", "not_in", "like", "not_like"}
+
+// ConvertRulesToJSON compiles the structured rule blocks into the minified rules
+// JSON that is uploaded by edgio_cdn_configuration.
+func ConvertRulesToJSON(rules []models.RuleModel) (string, error) {
+	natives := make([]interface{}, 0, len(rules))
+
+	for i, rule := range rules {
+		native, err := convertRuleToNative(rule)
+		if err != nil {
+			return "", fmt.Errorf("rule %d: %w", i, err)
+		}
+		natives = append(natives, native)
+	}
+
+	rulesJSON, err := json.Marshal(natives)
+	if err != nil {
+		return "", err
+	}
+
+	return MinifyJSON(string(rulesJSON))
+}
+
+func convertRuleToNative(rule models.RuleModel) (interface{}, error) {
+	features, err := convertRuleFeaturesToNative(rule.Features)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rule.Conditions) == 0 {
+		if len(rule.ElseIf) > 0 || rule.Else != nil {
+			return nil, fmt.Errorf("elseif and else require the rule to have a condition")
+		}
+		return features, nil
+	}
+
+	condition, err := convertRuleConditionsToNative(rule.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []interface{}{condition, features}
+
+	for i, elseIf := range rule.ElseIf {
+		if len(elseIf.Conditions) == 0 {
+			return nil, fmt.Errorf("elseif %d: at least one condition is required", i)
+		}
+
+		elseIfCondition, err := convertRuleConditionsToNative(elseIf.Conditions)
+		if err != nil {
+			return nil, fmt.Errorf("elseif %d: %w", i, err)
+		}
+
+		elseIfFeatures, err := convertRuleFeaturesToNative(elseIf.Features)
+		if err != nil {
+			return nil, fmt.Errorf("elseif %d: %w", i, err)
+		}
+
+		branches = append(branches, map[string]interface{}{
+			"elseif": []interface{}{elseIfCondition, elseIfFeatures},
+		})
+	}
+
+	if rule.Else != nil {
+		elseFeatures, err := convertRuleFeaturesToNative(rule.Else)
+		if err != nil {
+			return nil, fmt.Errorf("else: %w", err)
+		}
+
+		branches = append(branches, map[string]interface{}{"else": elseFeatures})
+	}
+
+	return map[string]interface{}{"if": branches}, nil
+}
+
+// convertRuleConditionsToNative combines multiple conditions with "and".
+func convertRuleConditionsToNative(conditions []models.RuleConditionModel) (interface{}, error) {
+	natives := make([]interface{}, 0, len(conditions))
+
+	for i, condition := range conditions {
+		native, err := convertRuleConditionToNative(condition)
+		if err != nil {
+			return nil, fmt.Errorf("condition %d: %w", i, err)
+		}
+		natives = append(natives, native)
+	}
+
+	if len(natives) == 1 {
+		return natives[0], nil
+	}
+
+	return map[string]interface{}{"and": natives}, nil
+}
+
+func convertRuleConditionToNative(condition models.RuleConditionModel) (interface{}, error) {
+	variable, ok := ruleVariables[condition.Variable.ValueString()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported variable %q", condition.Variable.ValueString())
+	}
+
+	value := variable.value
+	if variable.named {
+		if condition.Name.ValueString() == "" {
+			return nil, fmt.Errorf("variable %q requires a name", condition.Variable.ValueString())
+		}
+		value = condition.Name.ValueString()
+	}
+
+	operator := condition.Operator.ValueString()
+	if operator == "" {
+		operator = "=="
+	}
+
+	var operand interface{}
+	switch operator {
+	case "in", "not_in":
+		if condition.Values.IsNull() {
+			return nil, fmt.Errorf("operator %q requires values", operator)
+		}
+		operand = nonNilStrings(TypesListToStringSlice(condition.Values))
+	case "==", "!=", "=~", "!~", "like", "not_like":
+		if condition.Value.IsNull() {
+			return nil, fmt.Errorf("operator %q requires a value", operator)
+		}
+		operand = condition.Value.ValueString()
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", operator)
+	}
+
+	return map[string]interface{}{
+		operator: []interface{}{
+			map[string]interface{}{variable.key: value},
+			operand,
+		},
+	}, nil
+}
+
+func convertRuleFeaturesToNative(features *models.RuleFeaturesModel) (map[string]interface{}, error) {
+	native := map[string]interface{}{}
+	if features == nil {
+		return native, nil
+	}
+
+	// Raw JSON features go first so that the typed attributes take precedence.
+	if !features.JSON.IsNull() && features.JSON.ValueString() != "" {
+		if err := json.Unmarshal([]byte(features.JSON.ValueString()), &native); err != nil {
+			return nil, fmt.Errorf("features json must be a JSON object: %w", err)
+		}
+	}
+
+	if caching := features.Caching; caching != nil {
+		group := featureGroup(native, "caching")
+		setMap(group, "max_age", caching.MaxAge)
+		setString(group, "client_max_age", caching.ClientMaxAge)
+		setString(group, "service_worker_max_age", caching.ServiceWorkerMaxAge)
+		setString(group, "stale_while_revalidate", caching.StaleWhileRevalidate)
+		setBool(group, "bypass_cache", caching.BypassCache)
+		setBool(group, "bypass_client_cache", caching.BypassClientCache)
+	}
+
+	if headers := features.Headers; headers != nil {
+		group := featureGroup(native, "headers")
+		setMap(group, "set_request_headers", headers.SetRequestHeaders)
+		setMap(group, "set_response_headers", headers.SetResponseHeaders)
+		setMap(group, "add_response_headers", headers.AddResponseHeaders)
+		setList(group, "remove_request_headers", headers.RemoveRequestHeaders)
+		setList(group, "remove_response_headers", headers.RemoveResponseHeaders)
+	}
+
+	if url := features.URL; url != nil {
+		group := featureGroup(native, "url")
+
+		if len(url.URLRewrite) > 0 {
+			var rewrites []interface{}
+			for _, rewrite := range url.URLRewrite {
+				item := map[string]interface{}{}
+				setString(item, "source", rewrite.Source)
+				setString(item, "destination", rewrite.Destination)
+				setString(item, "syntax", rewrite.Syntax)
+				rewrites = append(rewrites, item)
+			}
+			group["url_rewrite"] = rewrites
+		}
+
+		if redirect := url.URLRedirect; redirect != nil {
+			item := map[string]interface{}{}
+			setInt64(item, "code", redirect.Code)
+			setString(item, "source", redirect.Source)
+			setString(item, "destination", redirect.Destination)
+			setString(item, "syntax", redirect.Syntax)
+			group["url_redirect"] = item
+		}
+	}
+
+	if origin := features.Origin; origin != nil {
+		setString(featureGroup(native, "origin"), "set_origin", origin.SetOrigin)
+	}
+
+	if response := features.Response; response != nil {
+		setInt64(featureGroup(native, "response"), "set_status_code", response.SetStatusCode)
+	}
+
+	if access := features.Access; access != nil {
+		setBool(featureGroup(native, "access"), "deny_access", access.DenyAccess)
+	}
+
+	setString(native, "edge_function", features.EdgeFunction)
+
+	return native, nil
+}
+
+// featureGroup returns the feature group object with the given name, creating
+// it (or replacing a non-object value from raw JSON) if needed.
+func featureGroup(features map[string]interface{}, name string) map[string]interface{} {
+	if group, ok := features[name].(map[string]interface{}); ok {
+		return group
+	}
+
+	group := map[string]interface{}{}
+	features[name] = group
+	return group
+}
+
+func setString(target map[string]interface{}, key string, value types.String) {
+	if !value.IsNull() && !value.IsUnknown() {
+		target[key] = value.ValueString()
+	}
+}
+
+func setBool(target map[string]interface{}, key string, value types.Bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		target[key] = value.ValueBool()
+	}
+}
+
+func setInt64(target map[string]interface{}, key string, value types.Int64) {
+	if !value.IsNull() && !value.IsUnknown() {
+		target[key] = value.ValueInt64()
+	}
+}
+
+func setMap(target map[string]interface{}, key string, value types.Map) {
+	if !value.IsNull() && !value.IsUnknown() {
+		target[key] = MapValueToStringMap(value)
+	}
+}
+
+func setList(target map[string]interface{}, key string, value types.List) {
+	if !value.IsNull() && !value.IsUnknown() {
+		target[key] = nonNilStrings(TypesListToStringSlice(value))
+	}
+}
+
+// nonNilStrings makes sure an empty list is encoded as [] rather than null.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
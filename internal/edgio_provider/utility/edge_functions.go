@@ -0,0 +1,156 @@
+package utility
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+const (
+	// MaxEdgeFunctionSourceSize limits a single edge function source or init
+	// script file.
+	MaxEdgeFunctionSourceSize = 1 << 20
+
+	// MaxEdgeFunctionsTotalSize limits all edge function sources of a CDN
+	// configuration together, including the init script.
+	MaxEdgeFunctionsTotalSize = 5 << 20
+)
+
+// ReadEdgeFunctionsDirectory reads every regular file below dir as an edge
+// function source, keyed by its slash separated path relative to dir. Hidden
+// files and directories are skipped.
+func ReadEdgeFunctionsDirectory(dir string) (map[string]string, error) {
+	sources := map[string]string{}
+	total := 0
+
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if filePath != dir && entry.Name()[0] == '.' {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+
+		source, err := readEdgeFunctionFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		total += len(source)
+		if total > MaxEdgeFunctionsTotalSize {
+			return fmt.Errorf("edge function sources in %s exceed the total limit of %d bytes", dir, MaxEdgeFunctionsTotalSize)
+		}
+
+		sources[filepath.ToSlash(relative)] = source
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%s does not contain any edge function sources", dir)
+	}
+
+	return sources, nil
+}
+
+// ReadEdgeFunctionInitScript reads the edge function init script from a file.
+func ReadEdgeFunctionInitScript(filePath string) (string, error) {
+	return readEdgeFunctionFile(filePath)
+}
+
+func readEdgeFunctionFile(filePath string) (string, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Size() > MaxEdgeFunctionSourceSize {
+		return "", fmt.Errorf("%s is %d bytes, larger than the limit of %d bytes", filePath, info.Size(), MaxEdgeFunctionSourceSize)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// EdgeFunctionsHash returns the hex encoded SHA-256 digest of the edge function
+// sources and the init script, which changes whenever any of them changes.
+func EdgeFunctionsHash(sources map[string]string, initScript string) string {
+	hash := sha256.New()
+
+	for _, name := range slices.Sorted(maps.Keys(sources)) {
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(name), name, len(sources[name]), sources[name])
+	}
+	fmt.Fprintf(hash, "init:%d:%s", len(initScript), initScript)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// RuleEdgeFunctionReference is an edge_function feature of the rules JSON.
+type RuleEdgeFunctionReference struct {
+	Pointer      string
+	EdgeFunction string
+}
+
+// RuleEdgeFunctionReferences lists the edge functions the rules hand requests
+// to, together with the JSON pointer of each edge_function.
+func RuleEdgeFunctionReferences(rules string) ([]RuleEdgeFunctionReference, error) {
+	document, err := customtypes.DecodeJSON(rules)
+	if err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	list, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rules must be a JSON array")
+	}
+
+	var references []RuleEdgeFunctionReference
+	for i, rule := range list {
+		forEachRuleFeatures(asObject(rule), fmt.Sprintf("/%d", i), func(features map[string]interface{}, pointer string) {
+			if edgeFunction, ok := features["edge_function"].(string); ok {
+				references = append(references, RuleEdgeFunctionReference{Pointer: pointer + "/edge_function", EdgeFunction: edgeFunction})
+			}
+		})
+	}
+
+	return references, nil
+}
+
+// HasEdgeFunctionSource reports whether sources contain the edge function a
+// rule refers to. Paths are compared cleaned, so "./main.js" matches "main.js".
+func HasEdgeFunctionSource(sources map[string]string, edgeFunction string) bool {
+	wanted := path.Clean(edgeFunction)
+	for name := range sources {
+		if path.Clean(name) == wanted {
+			return true
+		}
+	}
+	return false
+}
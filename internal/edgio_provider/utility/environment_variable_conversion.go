@@ -0,0 +1,32 @@
+package utility
+
+import (
+	"terraform-provider-edgio/internal/edgio_api/dtos"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ConvertEnvironmentVariableToModel converts the API response, keeping the value
+// attributes of prior. The API does not return secret values and write-only
+// values are never stored, so only a non-secret value that prior tracks (or an
+// imported variable, which has no key yet) is taken from the response.
+func ConvertEnvironmentVariableToModel(variable *dtos.EnvironmentVariable, prior models.EnvironmentVariableModel) models.EnvironmentVariableModel {
+	value := prior.Value
+	if !variable.Secret && (!prior.Value.IsNull() || prior.Key.IsNull()) {
+		value = types.StringValue(variable.Value)
+	}
+
+	return models.EnvironmentVariableModel{
+		Id:             types.StringValue(variable.Id),
+		EnvironmentID:  types.StringValue(variable.EnvironmentID),
+		Key:            types.StringValue(variable.Key),
+		Value:          value,
+		ValueWO:        types.StringNull(),
+		ValueWOVersion: prior.ValueWOVersion,
+		Secret:         types.BoolValue(variable.Secret),
+		CreatedAt:      types.StringValue(variable.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt:      types.StringValue(variable.UpdatedAt.Format(time.RFC3339)),
+	}
+}
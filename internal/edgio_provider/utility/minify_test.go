@@ -0,0 +1,43 @@
+package utility_test
+
+import (
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+func TestMinifyJSON(t *testing.T) {
+	tests := map[string]struct {
+		json      string
+		canonical string
+	}{
+		"whitespace and key order": {json: `{ "b": 1, "a": [ 1, 2 ] }`, canonical: `{"a":[1,2],"b":1}`},
+		"integral float":           {json: `1.0`, canonical: `1`},
+		"exponent":                 {json: `4.04e2`, canonical: `404`},
+		"negative zero":            {json: `-0.0`, canonical: `0`},
+		"fraction":                 {json: `0.50`, canonical: `0.5`},
+		"large integer":            {json: `12345678901234567890123`, canonical: `12345678901234567890123`},
+		"large float":              {json: `1.5e20`, canonical: `1.5e+20`},
+		"small float":              {json: `1e-7`, canonical: `1e-07`},
+		"strings are kept":         {json: `{"x":"1.0"}`, canonical: `{"x":"1.0"}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			canonical, err := utility.MinifyJSON(test.json)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if canonical != test.canonical {
+				t.Errorf("expected %s, got %s", test.canonical, canonical)
+			}
+		})
+	}
+}
+
+func TestMinifyJSON_InvalidJSON(t *testing.T) {
+	if _, err := utility.MinifyJSON(`{"a":`); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
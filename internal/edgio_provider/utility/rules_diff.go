@@ -0,0 +1,166 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+// DiffRulesJSON compares two rules arrays and returns a readable description
+// of the rules that were added, removed or changed. The rules are aligned by a
+// longest common subsequence of their canonical JSON, so inserting or removing
+// a rule does not show every following rule as changed. A removed rule directly
+// followed by an added one is shown as changed, with a line diff of their
+// indented JSON. Added and changed rules are numbered by their planned index,
+// removed rules by their prior index. An empty string means the rules are
+// semantically equal.
+func DiffRulesJSON(oldRules, newRules string) (string, error) {
+	oldList, err := decodeRulesList(oldRules)
+	if err != nil {
+		return "", fmt.Errorf("prior rules: %w", err)
+	}
+
+	newList, err := decodeRulesList(newRules)
+	if err != nil {
+		return "", fmt.Errorf("planned rules: %w", err)
+	}
+
+	var diff strings.Builder
+
+	ops := alignLines(compactRules(oldList), compactRules(newList))
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+
+		var removed, added []int
+		for ; k < len(ops) && ops[k].kind != ' '; k++ {
+			if ops[k].kind == '-' {
+				removed = append(removed, ops[k].a)
+			} else {
+				added = append(added, ops[k].b)
+			}
+		}
+
+		changed := min(len(removed), len(added))
+		for n := 0; n < changed; n++ {
+			fmt.Fprintf(&diff, "rule %d changed:\n%s", added[n], diffLines(indentRule(oldList[removed[n]]), indentRule(newList[added[n]])))
+		}
+		for _, i := range removed[changed:] {
+			fmt.Fprintf(&diff, "rule %d removed:\n%s", i, prefixLines("- ", indentRule(oldList[i])))
+		}
+		for _, i := range added[changed:] {
+			fmt.Fprintf(&diff, "rule %d added:\n%s", i, prefixLines("+ ", indentRule(newList[i])))
+		}
+	}
+
+	return diff.String(), nil
+}
+
+// decodeRulesList decodes a rules array, treating any other JSON value as a
+// single rule so that the diff still works on malformed prior state.
+func decodeRulesList(rules string) ([]interface{}, error) {
+	if strings.TrimSpace(rules) == "" {
+		return nil, nil
+	}
+
+	obj, err := customtypes.DecodeJSON(rules)
+	if err != nil {
+		return nil, err
+	}
+	obj = customtypes.CanonicalizeJSON(obj)
+
+	if list, ok := obj.([]interface{}); ok {
+		return list, nil
+	}
+
+	return []interface{}{obj}, nil
+}
+
+// compactRules returns the canonical JSON of every rule, for aligning them.
+func compactRules(rules []interface{}) []string {
+	compact := make([]string, len(rules))
+	for i, rule := range rules {
+		encoded, _ := json.Marshal(rule)
+		compact[i] = string(encoded)
+	}
+	return compact
+}
+
+func indentRule(rule interface{}) string {
+	indented, _ := json.MarshalIndent(rule, "", "  ")
+	return string(indented)
+}
+
+func prefixLines(prefix, text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+	return b.String()
+}
+
+// diffLines produces a minimal line diff based on the longest common
+// subsequence, which is plenty for documents the size of a single rule.
+func diffLines(oldText, newText string) string {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	var diff strings.Builder
+	for _, op := range alignLines(a, b) {
+		if op.kind == '+' {
+			diff.WriteString("+ " + b[op.b] + "\n")
+		} else {
+			diff.WriteString(string(op.kind) + " " + a[op.a] + "\n")
+		}
+	}
+
+	return diff.String()
+}
+
+// alignOp is one step of an alignment: ' ' keeps a[a] which equals b[b], '-'
+// removes a[a] and '+' adds b[b].
+type alignOp struct {
+	kind byte
+	a, b int
+}
+
+// alignLines aligns two sequences by their longest common subsequence. Within
+// a run of differences removals come before additions.
+func alignLines(a, b []string) []alignOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []alignOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, alignOp{' ', i, j})
+			i++
+			j++
+		case i < len(a) && (j >= len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, alignOp{'-', i, j})
+			i++
+		default:
+			ops = append(ops, alignOp{'+', i, j})
+			j++
+		}
+	}
+
+	return ops
+}
@@ -0,0 +1,80 @@
+package utility_test
+
+import (
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+func TestDiffRulesJSON(t *testing.T) {
+	tests := map[string]struct {
+		oldRules string
+		newRules string
+		diff     string
+	}{
+		"semantically equal": {
+			oldRules: `[{"caching":{"max_age":{"200":"1h"}},"response":{"set_status_code":404}}]`,
+			newRules: `[ { "response": { "set_status_code": 4.04e2 }, "caching": { "max_age": { "200": "1h" } } } ]`,
+			diff:     "",
+		},
+		"changed": {
+			oldRules: `[{"caching":{"max_age":"1h"}}]`,
+			newRules: `[{"caching":{"max_age":"2h"}}]`,
+			diff: "rule 0 changed:\n" +
+				"  {\n" +
+				"    \"caching\": {\n" +
+				"-     \"max_age\": \"1h\"\n" +
+				"+     \"max_age\": \"2h\"\n" +
+				"    }\n" +
+				"  }\n",
+		},
+		"added": {
+			oldRules: `[]`,
+			newRules: `[{"a":1}]`,
+			diff:     "rule 0 added:\n+ {\n+   \"a\": 1\n+ }\n",
+		},
+		"removed": {
+			oldRules: `[{"a":1},{"b":2}]`,
+			newRules: `[{"a":1}]`,
+			diff:     "rule 1 removed:\n- {\n-   \"b\": 2\n- }\n",
+		},
+		"inserted": {
+			oldRules: `[{"a":1},{"b":2}]`,
+			newRules: `[{"c":3},{"a":1},{"b":2}]`,
+			diff:     "rule 0 added:\n+ {\n+   \"c\": 3\n+ }\n",
+		},
+		"inserted and changed": {
+			oldRules: `[{"a":1},{"b":2},{"d":4}]`,
+			newRules: `[{"a":1},{"c":3},{"b":2},{"d":5}]`,
+			diff: "rule 1 added:\n+ {\n+   \"c\": 3\n+ }\n" +
+				"rule 3 changed:\n  {\n-   \"d\": 4\n+   \"d\": 5\n  }\n",
+		},
+		"empty prior rules": {
+			oldRules: ``,
+			newRules: `[{"a":1}]`,
+			diff:     "rule 0 added:\n+ {\n+   \"a\": 1\n+ }\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff, err := utility.DiffRulesJSON(test.oldRules, test.newRules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff != test.diff {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", test.diff, diff)
+			}
+		})
+	}
+}
+
+func TestDiffRulesJSON_InvalidJSON(t *testing.T) {
+	if _, err := utility.DiffRulesJSON(`[`, `[]`); err == nil {
+		t.Error("expected an error for invalid prior rules")
+	}
+	if _, err := utility.DiffRulesJSON(`[]`, `[`); err == nil {
+		t.Error("expected an error for invalid planned rules")
+	}
+}
@@ -0,0 +1,444 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+// SimulatedRequest is the synthetic request the rules are evaluated against.
+type SimulatedRequest struct {
+	Method  string
+	Scheme  string
+	Host    string
+	Path    string
+	Query   string
+	Headers map[string]string
+	Cookies map[string]string
+	Country string
+}
+
+// RulesSimulation is the outcome of evaluating a rules array against a request.
+// Features holds the features of all matched rules, merged in rule order so that
+// later rules override earlier ones like they do on the edge.
+type RulesSimulation struct {
+	MatchedRules []int
+	Features     map[string]interface{}
+}
+
+// SimulateRules evaluates every rule of the rules JSON against the request.
+// Only the subset of the rules language the provider knows about is supported,
+// unsupported operators and variables are reported as errors rather than being
+// silently treated as non-matching.
+func SimulateRules(rules string, request SimulatedRequest) (*RulesSimulation, error) {
+	document, err := customtypes.DecodeJSON(rules)
+	if err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	list, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rules must be a JSON array")
+	}
+
+	simulation := &RulesSimulation{
+		MatchedRules: []int{},
+		Features:     map[string]interface{}{},
+	}
+
+	for i, rule := range list {
+		features, matched, err := evaluateRule(rule, &request)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		if matched {
+			simulation.MatchedRules = append(simulation.MatchedRules, i)
+			mergeFeatures(simulation.Features, features)
+		}
+	}
+
+	return simulation, nil
+}
+
+// evaluateRule returns the features of the branch that applies to the request.
+// A rule without "if" applies to every request.
+func evaluateRule(rule interface{}, request *SimulatedRequest) (map[string]interface{}, bool, error) {
+	ruleObject, ok := rule.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("rule must be a JSON object")
+	}
+
+	branches, ok := ruleObject["if"]
+	if !ok {
+		return ruleObject, true, nil
+	}
+
+	ifBranch, ok := branches.([]interface{})
+	if !ok || len(ifBranch) < 2 {
+		return nil, false, fmt.Errorf("if must be an array of a condition and features")
+	}
+
+	matched, err := evaluateCondition(ifBranch[0], request)
+	if err != nil || matched {
+		return asObject(ifBranch[1]), matched, err
+	}
+
+	for _, branch := range ifBranch[2:] {
+		branchObject, ok := branch.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("if branches must be elseif or else objects")
+		}
+
+		if elseIf, ok := branchObject["elseif"].([]interface{}); ok {
+			if len(elseIf) != 2 {
+				return nil, false, fmt.Errorf("elseif must be an array of a condition and features")
+			}
+
+			matched, err := evaluateCondition(elseIf[0], request)
+			if err != nil || matched {
+				return asObject(elseIf[1]), matched, err
+			}
+			continue
+		}
+
+		if elseFeatures, ok := branchObject["else"]; ok {
+			return asObject(elseFeatures), true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+func evaluateCondition(condition interface{}, request *SimulatedRequest) (bool, error) {
+	conditionObject, ok := condition.(map[string]interface{})
+	if !ok || len(conditionObject) != 1 {
+		return false, fmt.Errorf("a condition must be an object with exactly one operator")
+	}
+
+	for operator, operand := range conditionObject {
+		switch operator {
+		case "and", "or":
+			conditions, ok := operand.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("%s expects an array of conditions", operator)
+			}
+
+			for _, nested := range conditions {
+				matched, err := evaluateCondition(nested, request)
+				if err != nil {
+					return false, err
+				}
+				if matched == (operator == "or") {
+					return matched, nil
+				}
+			}
+			return operator == "and", nil
+		case "not":
+			if conditions, ok := operand.([]interface{}); ok {
+				if len(conditions) != 1 {
+					return false, fmt.Errorf("not expects a single condition")
+				}
+				operand = conditions[0]
+			}
+
+			matched, err := evaluateCondition(operand, request)
+			return !matched, err
+		default:
+			arguments, ok := operand.([]interface{})
+			if !ok || len(arguments) != 2 {
+				return false, fmt.Errorf("%s expects a variable and a value", operator)
+			}
+
+			actual, isPath, err := resolveVariable(arguments[0], request)
+			if err != nil {
+				return false, err
+			}
+
+			return compare(operator, actual, isPath, arguments[1])
+		}
+	}
+
+	return false, nil
+}
+
+// resolveVariable returns the value of a rules variable for the request and
+// whether it is the request path, which "==" matches as a path pattern.
+func resolveVariable(variable interface{}, request *SimulatedRequest) (string, bool, error) {
+	variableObject, ok := variable.(map[string]interface{})
+	if !ok || len(variableObject) != 1 {
+		return "", false, fmt.Errorf("a variable must be an object with exactly one key")
+	}
+
+	for key, value := range variableObject {
+		name, ok := value.(string)
+		if !ok {
+			return "", false, fmt.Errorf("variable %s must name a string", key)
+		}
+
+		switch key {
+		case "request":
+			switch name {
+			case "path":
+				return request.Path, true, nil
+			case "method":
+				return request.Method, false, nil
+			case "scheme":
+				return request.Scheme, false, nil
+			case "query":
+				return request.Query, false, nil
+			}
+		case "request.header":
+			for header, headerValue := range request.Headers {
+				if strings.EqualFold(header, name) {
+					return headerValue, false, nil
+				}
+			}
+			if strings.EqualFold(name, "host") {
+				return request.Host, false, nil
+			}
+			return "", false, nil
+		case "request.cookie":
+			return request.Cookies[name], false, nil
+		case "request.querystring":
+			query, err := url.ParseQuery(request.Query)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid query string: %w", err)
+			}
+			return query.Get(name), false, nil
+		case "location":
+			if name == "country" {
+				return request.Country, false, nil
+			}
+		}
+
+		return "", false, fmt.Errorf("unsupported variable {%q: %q}", key, name)
+	}
+
+	return "", false, nil
+}
+
+func compare(operator, actual string, isPath bool, expected interface{}) (bool, error) {
+	switch operator {
+	case "==", "!=":
+		expectedString := jsonScalarString(expected)
+		matched := actual == expectedString
+		if isPath {
+			pattern, err := compilePathPattern(expectedString)
+			if err != nil {
+				return false, err
+			}
+			matched = pattern.MatchString(actual)
+		}
+		return matched == (operator == "=="), nil
+	case "=~", "!~":
+		pattern, err := regexp.Compile(jsonScalarString(expected))
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return pattern.MatchString(actual) == (operator == "=~"), nil
+	case "like", "not_like":
+		pattern := "^" + strings.ReplaceAll(regexp.QuoteMeta(jsonScalarString(expected)), `\*`, ".*") + "$"
+		return regexp.MustCompile(pattern).MatchString(actual) == (operator == "like"), nil
+	case "in", "not_in":
+		values, ok := expected.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("%s expects an array of values", operator)
+		}
+
+		found := false
+		for _, value := range values {
+			if jsonScalarString(value) == actual {
+				found = true
+				break
+			}
+		}
+		return found == (operator == "in"), nil
+	case "<", "<=", ">", ">=":
+		left, leftErr := strconv.ParseFloat(actual, 64)
+		right, rightErr := strconv.ParseFloat(jsonScalarString(expected), 64)
+		if leftErr != nil || rightErr != nil {
+			return false, nil
+		}
+
+		switch operator {
+		case "<":
+			return left < right, nil
+		case "<=":
+			return left <= right, nil
+		case ">":
+			return left > right, nil
+		default:
+			return left >= right, nil
+		}
+	}
+
+	return false, fmt.Errorf("unsupported operator %q", operator)
+}
+
+// pathPatternToken matches the named parameters (":name", ":name(regexp)" with
+// an optional "?", "*" or "+" modifier), unnamed groups and bare wildcards of
+// the path-to-regexp syntax.
+var pathPatternToken = regexp.MustCompile(`:([A-Za-z0-9_]+)(\([^)]*\))?([?*+])?|\(([^)]*)\)|\*`)
+
+// compilePathPattern converts the path-to-regexp subset used by Edgio rules into
+// a regular expression. Like path-to-regexp it is case insensitive and accepts
+// an optional trailing slash.
+func compilePathPattern(pattern string) (*regexp.Regexp, error) {
+	var expression strings.Builder
+	last := 0
+
+	for _, match := range pathPatternToken.FindAllStringSubmatchIndex(pattern, -1) {
+		literal := pattern[last:match[0]]
+		last = match[1]
+
+		token := pattern[match[0]:match[1]]
+		switch {
+		case token == "*":
+			expression.WriteString(regexp.QuoteMeta(literal) + "(.*)")
+		case token[0] == '(':
+			expression.WriteString(regexp.QuoteMeta(literal) + "(" + pattern[match[8]:match[9]] + ")")
+		default:
+			name := pattern[match[2]:match[3]]
+			segment := "[^/]+"
+			if match[4] >= 0 {
+				segment = pattern[match[4]+1 : match[5]-1]
+			}
+
+			modifier := ""
+			if match[6] >= 0 {
+				modifier = pattern[match[6]:match[7]]
+			}
+
+			// A "/" in front of an optional or repeated parameter belongs to it.
+			prefix := ""
+			if modifier != "" && strings.HasSuffix(literal, "/") {
+				literal, prefix = strings.TrimSuffix(literal, "/"), "/"
+			}
+			expression.WriteString(regexp.QuoteMeta(literal))
+
+			group := fmt.Sprintf("(?P<%s>%s)", name, segment)
+			repeated := fmt.Sprintf("(?P<%s>%s(?:/%s)*)", name, segment, segment)
+			switch modifier {
+			case "":
+				expression.WriteString(group)
+			case "?":
+				expression.WriteString("(?:" + prefix + group + ")?")
+			case "*":
+				expression.WriteString("(?:" + prefix + repeated + ")?")
+			case "+":
+				expression.WriteString(prefix + repeated)
+			}
+		}
+	}
+	expression.WriteString(regexp.QuoteMeta(pattern[last:]))
+
+	compiled, err := regexp.Compile("(?i)^" + strings.TrimSuffix(expression.String(), "/") + "/?$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+	}
+
+	return compiled, nil
+}
+
+// destinationParameter matches the ":name" references in a url_rewrite or
+// url_redirect destination, including any modifier copied from the source.
+var destinationParameter = regexp.MustCompile(`:[A-Za-z0-9_]+[?*+]?`)
+
+// ApplyURLMapping matches path against the source of a url_rewrite or
+// url_redirect feature and returns the destination with the captured
+// parameters substituted. A mapping without a source applies to every path.
+func ApplyURLMapping(mapping map[string]interface{}, path string) (string, bool, error) {
+	destination, _ := mapping["destination"].(string)
+	source, _ := mapping["source"].(string)
+	if source == "" {
+		return destination, true, nil
+	}
+
+	if syntax, _ := mapping["syntax"].(string); syntax == "regexp" {
+		pattern, err := regexp.Compile(source)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid regular expression: %w", err)
+		}
+
+		submatches := pattern.FindStringSubmatchIndex(path)
+		if submatches == nil {
+			return "", false, nil
+		}
+		return string(pattern.ExpandString(nil, destination, path, submatches)), true, nil
+	}
+
+	pattern, err := compilePathPattern(source)
+	if err != nil {
+		return "", false, err
+	}
+
+	submatches := pattern.FindStringSubmatch(path)
+	if submatches == nil {
+		return "", false, nil
+	}
+
+	values := map[string]string{}
+	for i, name := range pattern.SubexpNames() {
+		if name != "" {
+			values[name] = submatches[i]
+		}
+	}
+
+	result := destinationParameter.ReplaceAllStringFunc(destination, func(parameter string) string {
+		name := strings.TrimRight(parameter[1:], "?*+")
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return parameter
+	})
+
+	return result, true, nil
+}
+
+func mergeFeatures(target, source map[string]interface{}) {
+	for key, value := range source {
+		sourceObject, sourceIsObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+
+		if sourceIsObject && targetIsObject {
+			mergeFeatures(targetObject, sourceObject)
+			continue
+		}
+
+		if sourceIsObject {
+			copied := map[string]interface{}{}
+			mergeFeatures(copied, sourceObject)
+			value = copied
+		}
+		target[key] = value
+	}
+}
+
+func asObject(value interface{}) map[string]interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object
+	}
+	return map[string]interface{}{}
+}
+
+func jsonScalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	}
+
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,193 @@
+package utility_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+func TestSimulateRules(t *testing.T) {
+	request := utility.SimulatedRequest{
+		Method:  "GET",
+		Scheme:  "https",
+		Host:    "cdn.example.com",
+		Path:    "/api/v1/users",
+		Query:   "page=2&sort=name",
+		Headers: map[string]string{"User-Agent": "curl/8.0"},
+		Cookies: map[string]string{"session": "abc"},
+		Country: "DE",
+	}
+
+	tests := map[string]struct {
+		rules   string
+		matched []int
+	}{
+		"unconditional": {
+			rules:   `[{"caching":{"max_age":{"200":"1h"}}}]`,
+			matched: []int{0},
+		},
+		"path pattern": {
+			rules:   `[{"if":[{"==":[{"request":"path"},"/api/:path*"]},{}]},{"if":[{"==":[{"request":"path"},"/static/:path*"]},{}]}]`,
+			matched: []int{0},
+		},
+		"path pattern is case insensitive with optional trailing slash": {
+			rules:   `[{"if":[{"==":[{"request":"path"},"/API/V1/USERS/"]},{}]}]`,
+			matched: []int{0},
+		},
+		"named parameter matches a single segment": {
+			rules:   `[{"if":[{"==":[{"request":"path"},"/api/:version"]},{}]},{"if":[{"==":[{"request":"path"},"/api/:version/:resource"]},{}]}]`,
+			matched: []int{1},
+		},
+		"not equal": {
+			rules:   `[{"if":[{"!=":[{"request":"method"},"POST"]},{}]}]`,
+			matched: []int{0},
+		},
+		"regular expression": {
+			rules:   `[{"if":[{"=~":[{"request.header":"user-agent"},"^curl/"]},{}]},{"if":[{"!~":[{"request.header":"user-agent"},"^curl/"]},{}]}]`,
+			matched: []int{0},
+		},
+		"like": {
+			rules:   `[{"if":[{"like":[{"request.header":"host"},"*.example.com"]},{}]},{"if":[{"not_like":[{"request.header":"host"},"*.example.com"]},{}]}]`,
+			matched: []int{0},
+		},
+		"in": {
+			rules:   `[{"if":[{"in":[{"location":"country"},["DE","FR"]]},{}]},{"if":[{"not_in":[{"location":"country"},["DE","FR"]]},{}]}]`,
+			matched: []int{0},
+		},
+		"numeric comparison": {
+			rules:   `[{"if":[{">=":[{"request.querystring":"page"},2]},{}]},{"if":[{"<":[{"request.querystring":"page"},2]},{}]}]`,
+			matched: []int{0},
+		},
+		"cookie": {
+			rules:   `[{"if":[{"==":[{"request.cookie":"session"},"abc"]},{}]}]`,
+			matched: []int{0},
+		},
+		"and or not": {
+			rules: `[{"if":[{"and":[{"==":[{"request":"scheme"},"https"]},{"not":{"==":[{"request":"method"},"POST"]}}]},{}]},` +
+				`{"if":[{"or":[{"==":[{"request":"scheme"},"http"]},{"==":[{"request":"method"},"POST"]}]},{}]}]`,
+			matched: []int{0},
+		},
+		"elseif branch": {
+			rules:   `[{"if":[{"==":[{"request":"method"},"POST"]},{},{"elseif":[{"==":[{"request":"method"},"GET"]},{}]}]}]`,
+			matched: []int{0},
+		},
+		"else branch": {
+			rules:   `[{"if":[{"==":[{"request":"method"},"POST"]},{},{"else":{}}]}]`,
+			matched: []int{0},
+		},
+		"no branch matches": {
+			rules:   `[{"if":[{"==":[{"request":"method"},"POST"]},{},{"elseif":[{"==":[{"request":"method"},"PUT"]},{}]}]}]`,
+			matched: []int{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			simulation, err := utility.SimulateRules(test.rules, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(simulation.MatchedRules, test.matched) {
+				t.Errorf("expected matched rules %v, got %v", test.matched, simulation.MatchedRules)
+			}
+		})
+	}
+}
+
+func TestSimulateRules_Features(t *testing.T) {
+	rules := `[
+		{"caching":{"max_age":{"200":"1h"},"bypass_client_cache":true}},
+		{"if":[{"==":[{"request":"method"},"POST"]},{"caching":{"max_age":{"200":"5m"}}},{"else":{"caching":{"max_age":{"404":"1m"}}}}]}
+	]`
+
+	simulation, err := utility.SimulateRules(rules, utility.SimulatedRequest{Method: "GET", Path: "/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Later rules override earlier ones, nested objects are merged.
+	caching, _ := simulation.Features["caching"].(map[string]interface{})
+	maxAge, _ := caching["max_age"].(map[string]interface{})
+	if maxAge["200"] != "1h" || maxAge["404"] != "1m" || caching["bypass_client_cache"] != true {
+		t.Errorf("unexpected merged features: %v", simulation.Features)
+	}
+}
+
+func TestSimulateRules_Errors(t *testing.T) {
+	tests := map[string]struct {
+		rules string
+		err   string
+	}{
+		"invalid JSON":         {rules: `[`, err: "not valid JSON"},
+		"not an array":         {rules: `{}`, err: "must be a JSON array"},
+		"rule not an object":   {rules: `[1]`, err: "rule 0: rule must be a JSON object"},
+		"unsupported operator": {rules: `[{"if":[{"~~":[{"request":"path"},"/"]},{}]}]`, err: `unsupported operator "~~"`},
+		"unsupported variable": {rules: `[{"if":[{"==":[{"request":"body"},"x"]},{}]}]`, err: "unsupported variable"},
+		"invalid regexp":       {rules: `[{"if":[{"=~":[{"request":"path"},"("]},{}]}]`, err: "invalid regular expression"},
+		"malformed if":         {rules: `[{"if":[{"==":[{"request":"path"},"/"]}]}]`, err: "if must be an array"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := utility.SimulateRules(test.rules, utility.SimulatedRequest{Path: "/"})
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestApplyURLMapping(t *testing.T) {
+	tests := map[string]struct {
+		mapping     map[string]interface{}
+		path        string
+		destination string
+		matched     bool
+	}{
+		"named parameters": {
+			mapping:     map[string]interface{}{"source": "/old/:category/:id", "destination": "/new/:id/:category"},
+			path:        "/old/books/42",
+			destination: "/new/42/books",
+			matched:     true,
+		},
+		"repeated parameter": {
+			mapping:     map[string]interface{}{"source": "/assets/:path*", "destination": "/static/:path*"},
+			path:        "/assets/css/site.css",
+			destination: "/static/css/site.css",
+			matched:     true,
+		},
+		"regexp syntax": {
+			mapping:     map[string]interface{}{"source": `^/v(\d+)/(.*)$`, "destination": "/api/$2?version=$1", "syntax": "regexp"},
+			path:        "/v2/users",
+			destination: "/api/users?version=2",
+			matched:     true,
+		},
+		"no source": {
+			mapping:     map[string]interface{}{"destination": "/maintenance"},
+			path:        "/anything",
+			destination: "/maintenance",
+			matched:     true,
+		},
+		"no match": {
+			mapping: map[string]interface{}{"source": "/old/:id", "destination": "/new/:id"},
+			path:    "/other/42",
+			matched: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			destination, matched, err := utility.ApplyURLMapping(test.mapping, test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if matched != test.matched || destination != test.destination {
+				t.Errorf("expected %q (%t), got %q (%t)", test.destination, test.matched, destination, matched)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-edgio/internal/edgio_provider/customtypes"
+)
+
+// Values of the rules_lint attribute of edgio_cdn_configuration.
+const (
+	RulesLintOff   = "off"
+	RulesLintWarn  = "warn"
+	RulesLintError = "error"
+)
+
+// RulesLintFinding is a single problem found by LintRules. Pointer is the JSON
+// pointer of the offending element within the rules array.
+type RulesLintFinding struct {
+	Pointer string
+	Message string
+}
+
+func (f RulesLintFinding) Error() string {
+	return fmt.Sprintf("%s: %s", f.Pointer, f.Message)
+}
+
+// LintRules looks for rules that are valid but most likely wrong: duplicate
+// rules and conditions, rules that can never take effect because an earlier
+// unconditional rule ends every request, set_origin references to origins that
+// are not among origins and contradicting caching features. A nil origins skips
+// the origin check, e.g. while the origin names are not known yet.
+func LintRules(rules string, origins []string) ([]RulesLintFinding, error) {
+	document, err := customtypes.DecodeJSON(rules)
+	if err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	list, ok := customtypes.CanonicalizeJSON(document).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rules must be a JSON array")
+	}
+
+	var definedOrigins map[string]bool
+	if origins != nil {
+		definedOrigins = make(map[string]bool, len(origins))
+		for _, origin := range origins {
+			definedOrigins[origin] = true
+		}
+	}
+
+	var findings []RulesLintFinding
+	seenRules := map[string]int{}
+	seenConditions := map[string]int{}
+
+	for i, rule := range list {
+		pointer := fmt.Sprintf("/%d", i)
+		ruleObject, _ := rule.(map[string]interface{})
+
+		ruleKey := canonicalString(rule)
+		if first, ok := seenRules[ruleKey]; ok {
+			findings = append(findings, RulesLintFinding{
+				Pointer: pointer,
+				Message: fmt.Sprintf("rule is a duplicate of rule %d", first),
+			})
+		} else {
+			seenRules[ruleKey] = i
+
+			if branches, ok := ruleObject["if"].([]interface{}); ok && len(branches) > 0 {
+				conditionKey := canonicalString(branches[0])
+				if first, ok := seenConditions[conditionKey]; ok {
+					findings = append(findings, RulesLintFinding{
+						Pointer: pointer + "/if/0",
+						Message: fmt.Sprintf("condition duplicates the condition of rule %d, consider merging the two rules", first),
+					})
+				} else {
+					seenConditions[conditionKey] = i
+				}
+			}
+		}
+
+		forEachRuleFeatures(ruleObject, pointer, func(features map[string]interface{}, featuresPointer string) {
+			findings = append(findings, lintFeatures(features, featuresPointer, definedOrigins)...)
+		})
+
+		if _, conditional := ruleObject["if"]; !conditional && i < len(list)-1 {
+			if reason := terminalFeature(ruleObject); reason != "" {
+				unreachable := fmt.Sprintf("rules %d to %d are", i+1, len(list)-1)
+				if i+1 == len(list)-1 {
+					unreachable = fmt.Sprintf("rule %d is", i+1)
+				}
+
+				findings = append(findings, RulesLintFinding{
+					Pointer: fmt.Sprintf("/%d", i+1),
+					Message: fmt.Sprintf("%s never reached because rule %d %s for every request", unreachable, i, reason),
+				})
+				break
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// forEachRuleFeatures calls fn for the features of every branch of the rule.
+func forEachRuleFeatures(rule map[string]interface{}, pointer string, fn func(map[string]interface{}, string)) {
+	branches, ok := rule["if"].([]interface{})
+	if !ok {
+		fn(rule, pointer)
+		return
+	}
+
+	if len(branches) > 1 {
+		fn(asObject(branches[1]), pointer+"/if/1")
+	}
+
+	for i := 2; i < len(branches); i++ {
+		branch := asObject(branches[i])
+		branchPointer := fmt.Sprintf("%s/if/%d", pointer, i)
+
+		if elseIf, ok := branch["elseif"].([]interface{}); ok && len(elseIf) > 1 {
+			fn(asObject(elseIf[1]), branchPointer+"/elseif/1")
+		}
+		if elseFeatures, ok := branch["else"]; ok {
+			fn(asObject(elseFeatures), branchPointer+"/else")
+		}
+	}
+}
+
+func lintFeatures(features map[string]interface{}, pointer string, origins map[string]bool) []RulesLintFinding {
+	var findings []RulesLintFinding
+
+	if origin, ok := asObject(features["origin"])["set_origin"].(string); ok && origins != nil && !origins[origin] && !IsBuiltInOrigin(origin) {
+		findings = append(findings, RulesLintFinding{
+			Pointer: pointer + "/origin/set_origin",
+			Message: fmt.Sprintf("origin %q is not defined in origins", origin),
+		})
+	}
+
+	caching := asObject(features["caching"])
+	if caching["bypass_cache"] == true && len(asObject(caching["max_age"])) > 0 {
+		findings = append(findings, RulesLintFinding{
+			Pointer: pointer + "/caching",
+			Message: "max_age has no effect because bypass_cache disables the edge cache",
+		})
+	}
+	if caching["bypass_client_cache"] == true && caching["client_max_age"] != nil {
+		findings = append(findings, RulesLintFinding{
+			Pointer: pointer + "/caching",
+			Message: "client_max_age has no effect because bypass_client_cache disables the browser cache",
+		})
+	}
+
+	return findings
+}
+
+// terminalFeature describes why the features end a request, or returns "" if
+// later rules still take effect.
+func terminalFeature(features map[string]interface{}) string {
+	if asObject(features["access"])["deny_access"] == true {
+		return "denies access"
+	}
+	if redirect, ok := asObject(features["url"])["url_redirect"].(map[string]interface{}); ok && redirect["source"] == nil {
+		return "redirects"
+	}
+	return ""
+}
+
+func canonicalString(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,105 @@
+package utility_test
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-edgio/internal/edgio_provider/utility"
+)
+
+func TestLintRules(t *testing.T) {
+	tests := map[string]struct {
+		rules    string
+		origins  []string
+		findings []utility.RulesLintFinding
+	}{
+		"no findings": {
+			rules: `[{"if":[{"==":[{"request":"path"},"/a"]},{"caching":{"max_age":{"200":"1h"}}}]},{"if":[{"==":[{"request":"path"},"/b"]},{"caching":{"bypass_cache":true}}]}]`,
+		},
+		"duplicate rule": {
+			rules: `[{"caching":{"max_age":{"200":"1h"}}},{"caching":{"max_age":{"200":"3600s"}}},{"caching":{"max_age":{"200":"1h"}}}]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/2", Message: "rule is a duplicate of rule 0"},
+			},
+		},
+		"duplicate rule with different formatting": {
+			rules: `[{"response":{"set_status_code":404}},{ "response": { "set_status_code": 4.04e2 } }]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/1", Message: "rule is a duplicate of rule 0"},
+			},
+		},
+		"duplicate condition": {
+			rules: `[{"if":[{"==":[{"request":"path"},"/a"]},{"caching":{"max_age":{"200":"1h"}}}]},{"if":[{"==":[{"request":"path"},"/a"]},{"headers":{"debug_header":true}}]}]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/1/if/0", Message: "condition duplicates the condition of rule 0, consider merging the two rules"},
+			},
+		},
+		"unreachable after deny access": {
+			rules: `[{"access":{"deny_access":true}},{"caching":{"max_age":{"200":"1h"}}},{"headers":{"debug_header":true}}]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/1", Message: "rules 1 to 2 are never reached because rule 0 denies access for every request"},
+			},
+		},
+		"unreachable after redirect": {
+			rules: `[{"url":{"url_redirect":{"code":301,"destination":"https://example.com"}}},{"caching":{"max_age":{"200":"1h"}}}]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/1", Message: "rule 1 is never reached because rule 0 redirects for every request"},
+			},
+		},
+		"conditional deny access": {
+			rules: `[{"if":[{"==":[{"request":"path"},"/admin"]},{"access":{"deny_access":true}}]},{"caching":{"max_age":{"200":"1h"}}}]`,
+		},
+		"redirect with source": {
+			rules: `[{"url":{"url_redirect":{"source":"/old","destination":"/new"}}},{"caching":{"max_age":{"200":"1h"}}}]`,
+		},
+		"contradicting caching in branches": {
+			rules: `[{"if":[{"==":[{"request":"path"},"/a"]},{"caching":{"bypass_cache":true,"max_age":{"200":"1h"}}},` +
+				`{"elseif":[{"==":[{"request":"path"},"/b"]},{"caching":{"bypass_client_cache":true,"client_max_age":"1h"}}]},` +
+				`{"else":{"caching":{"bypass_cache":true,"max_age":{"200":"1h"}}}}]}]`,
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/0/if/1/caching", Message: "max_age has no effect because bypass_cache disables the edge cache"},
+				{Pointer: "/0/if/2/elseif/1/caching", Message: "client_max_age has no effect because bypass_client_cache disables the browser cache"},
+				{Pointer: "/0/if/3/else/caching", Message: "max_age has no effect because bypass_cache disables the edge cache"},
+			},
+		},
+		"undefined origin": {
+			rules:   `[{"origin":{"set_origin":"web"}},{"if":[{"==":[{"request":"path"},"/api"]},{"origin":{"set_origin":"api"}},{"else":{"origin":{"set_origin":"edgio_serverless"}}}]}]`,
+			origins: []string{"web"},
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/1/if/1/origin/set_origin", Message: `origin "api" is not defined in origins`},
+			},
+		},
+		"no origins defined": {
+			rules:   `[{"origin":{"set_origin":"web"}}]`,
+			origins: []string{},
+			findings: []utility.RulesLintFinding{
+				{Pointer: "/0/origin/set_origin", Message: `origin "web" is not defined in origins`},
+			},
+		},
+		"origins not known": {
+			rules: `[{"origin":{"set_origin":"web"}}]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			findings, err := utility.LintRules(test.rules, test.origins)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(findings, test.findings) {
+				t.Errorf("expected findings %v, got %v", test.findings, findings)
+			}
+		})
+	}
+}
+
+func TestLintRules_Errors(t *testing.T) {
+	if _, err := utility.LintRules(`[`, nil); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if _, err := utility.LintRules(`{}`, nil); err == nil {
+		t.Error("expected an error for rules that are not an array")
+	}
+}
@@ -0,0 +1,107 @@
+package utility
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/rules.schema.json
+var rulesSchemaJSON string
+
+const rulesSchemaURL = "https://edgio.app/schemas/rules.schema.json"
+
+var (
+	rulesSchema     *jsonschema.Schema
+	rulesSchemaErr  error
+	rulesSchemaOnce sync.Once
+)
+
+// RulesSchemaError is a single violation of the rules JSON schema. Pointer is
+// the JSON pointer of the offending element, "" being the rules array itself.
+type RulesSchemaError struct {
+	Pointer string
+	Message string
+}
+
+func (e RulesSchemaError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, e.Message)
+}
+
+func compiledRulesSchema() (*jsonschema.Schema, error) {
+	rulesSchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource(rulesSchemaURL, strings.NewReader(rulesSchemaJSON)); err != nil {
+			rulesSchemaErr = err
+			return
+		}
+		rulesSchema, rulesSchemaErr = compiler.Compile(rulesSchemaURL)
+	})
+
+	return rulesSchema, rulesSchemaErr
+}
+
+// ValidateRulesJSON validates the rules JSON against the rules schema embedded in
+// the provider. The schema is maintained with the provider and lists the rule
+// features known to it, so the API may accept rules that violate it. The
+// returned error is only set if the rules are not valid JSON, schema
+// violations are returned as a list sorted by pointer.
+func ValidateRulesJSON(rules string) ([]RulesSchemaError, error) {
+	var document interface{}
+	decoder := json.NewDecoder(strings.NewReader(rules))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("rules are not valid JSON: %w", err)
+	}
+
+	schema, err := compiledRulesSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile the rules schema: %w", err)
+	}
+
+	err = schema.Validate(document)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	seen := map[RulesSchemaError]bool{}
+	var violations []RulesSchemaError
+	collectRulesSchemaErrors(validationErr, seen, &violations)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Pointer < violations[j].Pointer
+	})
+
+	return violations, nil
+}
+
+// collectRulesSchemaErrors keeps only the leaf errors, the intermediate ones just
+// repeat that a subschema failed.
+func collectRulesSchemaErrors(err *jsonschema.ValidationError, seen map[RulesSchemaError]bool, violations *[]RulesSchemaError) {
+	if len(err.Causes) == 0 {
+		violation := RulesSchemaError{Pointer: err.InstanceLocation, Message: err.Message}
+		if !seen[violation] {
+			seen[violation] = true
+			*violations = append(*violations, violation)
+		}
+		return
+	}
+
+	for _, cause := range err.Causes {
+		collectRulesSchemaErrors(cause, seen, violations)
+	}
+}
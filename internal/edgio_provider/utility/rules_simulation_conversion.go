@@ -0,0 +1,127 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"terraform-provider-edgio/internal/edgio_provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func ConvertSimulatedRequestToNative(model *models.SimulatedRequestModel) SimulatedRequest {
+	request := SimulatedRequest{
+		Method:  "GET",
+		Scheme:  "https",
+		Host:    model.Host.ValueString(),
+		Path:    model.Path.ValueString(),
+		Query:   model.Query.ValueString(),
+		Headers: MapValueToStringMap(model.Headers),
+		Cookies: MapValueToStringMap(model.Cookies),
+		Country: model.Country.ValueString(),
+	}
+
+	if !model.Method.IsNull() {
+		request.Method = model.Method.ValueString()
+	}
+
+	if !model.Scheme.IsNull() {
+		request.Scheme = model.Scheme.ValueString()
+	}
+
+	return request
+}
+
+// ConvertRulesSimulationToModel fills the computed attributes of the
+// edgio_rules_simulation data source from the merged features.
+func ConvertRulesSimulationToModel(simulation *RulesSimulation, request SimulatedRequest, model *models.RulesSimulationModel) error {
+	matched := make([]attr.Value, len(simulation.MatchedRules))
+	for i, index := range simulation.MatchedRules {
+		matched[i] = types.Int64Value(int64(index))
+	}
+	model.MatchedRules = types.ListValueMust(types.Int64Type, matched)
+
+	features, err := json.Marshal(simulation.Features)
+	if err != nil {
+		return err
+	}
+	model.Features = types.StringValue(string(features))
+
+	caching := asObject(simulation.Features["caching"])
+	headers := asObject(simulation.Features["headers"])
+	url := asObject(simulation.Features["url"])
+
+	model.Origin = featureString(asObject(simulation.Features["origin"]), "set_origin")
+	model.MaxAge = featureStringMap(caching, "max_age")
+	model.BypassCache = types.BoolNull()
+	if bypass, ok := caching["bypass_cache"].(bool); ok {
+		model.BypassCache = types.BoolValue(bypass)
+	}
+	model.RequestHeaders = featureStringMap(headers, "set_request_headers")
+
+	responseHeaders := map[string]string{}
+	for _, key := range []string{"set_response_headers", "add_response_headers"} {
+		for name, value := range asObject(headers[key]) {
+			responseHeaders[name] = jsonScalarString(value)
+		}
+	}
+	model.ResponseHeaders = StringMapToMapValue(&responseHeaders)
+
+	model.StatusCode = featureInt64(asObject(simulation.Features["response"]), "set_status_code")
+	model.EdgeFunction = featureString(simulation.Features, "edge_function")
+
+	model.RewrittenPath = types.StringValue(request.Path)
+	if rewrites, ok := url["url_rewrite"].([]interface{}); ok {
+		for _, rewrite := range rewrites {
+			path, applied, err := ApplyURLMapping(asObject(rewrite), request.Path)
+			if err != nil {
+				return fmt.Errorf("url_rewrite: %w", err)
+			}
+			if applied {
+				model.RewrittenPath = types.StringValue(path)
+				break
+			}
+		}
+	}
+
+	model.RedirectCode = types.Int64Null()
+	model.RedirectLocation = types.StringNull()
+	if redirect, ok := url["url_redirect"].(map[string]interface{}); ok {
+		location, applied, err := ApplyURLMapping(redirect, request.Path)
+		if err != nil {
+			return fmt.Errorf("url_redirect: %w", err)
+		}
+		if applied {
+			model.RedirectLocation = types.StringValue(location)
+			model.RedirectCode = featureInt64(redirect, "code")
+			if model.RedirectCode.IsNull() {
+				model.RedirectCode = types.Int64Value(301)
+			}
+		}
+	}
+
+	return nil
+}
+
+func featureString(features map[string]interface{}, key string) types.String {
+	if value, ok := features[key]; ok {
+		return types.StringValue(jsonScalarString(value))
+	}
+	return types.StringNull()
+}
+
+func featureInt64(features map[string]interface{}, key string) types.Int64 {
+	if value, err := strconv.ParseInt(jsonScalarString(features[key]), 10, 64); err == nil {
+		return types.Int64Value(value)
+	}
+	return types.Int64Null()
+}
+
+func featureStringMap(features map[string]interface{}, key string) types.Map {
+	values := map[string]string{}
+	for name, value := range asObject(features[key]) {
+		values[name] = jsonScalarString(value)
+	}
+	return StringMapToMapValue(&values)
+}
@@ -0,0 +1,164 @@
+package utility
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+const (
+	KeyAlgorithmRSA   = "RSA"
+	KeyAlgorithmECDSA = "ECDSA"
+)
+
+// GeneratePrivateKey creates a new private key for the given algorithm. rsaBits
+// is only used for RSA keys and ecdsaCurve only for ECDSA keys.
+func GeneratePrivateKey(algorithm string, rsaBits int, ecdsaCurve string) (crypto.Signer, error) {
+	switch strings.ToUpper(algorithm) {
+	case KeyAlgorithmRSA:
+		if rsaBits < 2048 {
+			return nil, fmt.Errorf("rsa_bits must be at least 2048, got %d", rsaBits)
+		}
+
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	case KeyAlgorithmECDSA:
+		curve, err := ellipticCurve(ecdsaCurve)
+		if err != nil {
+			return nil, err
+		}
+
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q, expected %s or %s", algorithm, KeyAlgorithmRSA, KeyAlgorithmECDSA)
+	}
+}
+
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch strings.ToUpper(name) {
+	case "P224":
+		return elliptic.P224(), nil
+	case "P256":
+		return elliptic.P256(), nil
+	case "P384":
+		return elliptic.P384(), nil
+	case "P521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA curve %q, expected one of P224, P256, P384, P521", name)
+	}
+}
+
+// EncodePrivateKeyPEM encodes the key as a PKCS #8 "PRIVATE KEY" PEM block.
+func EncodePrivateKeyPEM(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// DecodePrivateKeyPEM parses a PKCS #8, PKCS #1 or SEC 1 encoded private key.
+func DecodePrivateKeyPEM(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("failed to parse private key of PEM type %q", block.Type)
+}
+
+// CreateCertificateRequestPEM builds a PEM encoded PKCS #10 certificate signing
+// request for the key, using dnsNames as Subject Alternative Names.
+func CreateCertificateRequestPEM(key crypto.Signer, commonName, organization string, dnsNames []string) (string, error) {
+	subject := pkix.Name{CommonName: commonName}
+	if organization != "" {
+		subject.Organization = []string{organization}
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:  subject,
+		DNSNames: dnsNames,
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// PublicKeyFingerprint returns the hex encoded SHA-256 digest of the DER encoded
+// public key, which identifies a key pair without exposing the private key.
+func PublicKeyFingerprint(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CertificateMatchesKey checks that the first certificate in certPEM was issued
+// for the public half of key.
+func CertificateMatchesKey(certPEM string, key crypto.Signer) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("certificate is not a PEM encoded CERTIFICATE block")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	certKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal certificate public key: %w", err)
+	}
+
+	ownKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	if string(certKey) != string(ownKey) {
+		return fmt.Errorf("certificate with serial %s was not issued for this private key", cert.SerialNumber)
+	}
+
+	return nil
+}
+
+// SecretFingerprint returns the hex encoded SHA-256 digest of a secret value so
+// that changes can be detected without storing the value itself.
+func SecretFingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
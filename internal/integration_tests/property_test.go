@@ -22,7 +22,7 @@ func TestProperty_Lifecycle(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
-			"edgio": providerserver.NewProtocol6WithError(edgio_provider.New()),
+			"edgio": providerserver.NewProtocol6WithError(edgio_provider.New("test")()),
 		},
 		Steps: []resource.TestStep{
 			{
@@ -7,39 +7,36 @@ import (
 	"context"
 	"flag"
 	"log"
-	"os"
 
 	"terraform-provider-edgio/internal/edgio_provider"
 
-	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
+var (
+	// version and address are set by goreleaser via ldflags, e.g.
+	// -X main.version=1.2.3 -X main.address=registry.terraform.io/Edgio/edgio
+	version = "dev"
+	address = "hashicorp.com/edu/edgio"
+)
+
 func main() {
 	var debugVar bool
 
-	// Output current  directory
-	s, _ := os.Getwd()
-	log.Println("Current directory: ", s)
-
 	flag.BoolVar(&debugVar, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
 	opts := providerserver.ServeOpts{
-		// NOTE: This is not a typical Terraform Registry provider address,
-		// such as registry.terraform.io/hashicorp/hashicups. This specific
-		// provider address is used in these tutorials in conjunction with a
-		// specific Terraform CLI configuration for manual development testing
-		// of this provider.
-		Address: "hashicorp.com/edu/edgio",
+		// NOTE: The default address is not a typical Terraform Registry
+		// provider address, such as registry.terraform.io/hashicorp/hashicups.
+		// It is used in conjunction with a specific Terraform CLI configuration
+		// for manual development testing of this provider, releases set the
+		// registry address.
+		Address: address,
 		Debug:   debugVar,
 	}
 
-	providerFunc := func() provider.Provider {
-		return edgio_provider.New()
-	}
-
-	err := providerserver.Serve(context.Background(), providerFunc, opts)
+	err := providerserver.Serve(context.Background(), edgio_provider.New(version), opts)
 
 	if err != nil {
 		log.Fatal(err.Error())